@@ -93,6 +93,7 @@ func registerSetConfigCmd(ctx *context.Context, configCmd *cobra.Command) {
 		Short:   "Set a global configuration value.",
 	}
 	registerSetNamespaceCmd(ctx, setCmd)
+	registerSetProfileCmd(ctx, setCmd)
 	registerSetWorkspaceModeCmd(ctx, setCmd)
 	registerSetLogModeCmd(ctx, setCmd)
 	registerSetTUICmd(ctx, setCmd)
@@ -125,6 +126,35 @@ func setNamespaceFunc(ctx *context.Context, _ *cobra.Command, args []string) {
 	logger.Log().PlainTextSuccess("Namespace set to " + namespace)
 }
 
+func registerSetProfileCmd(ctx *context.Context, setCmd *cobra.Command) {
+	profileCmd := &cobra.Command{
+		Use:   "profile [NAME]",
+		Short: "Change the workspace environment profile used when running executables.",
+		Long: "Change the workspace environment profile used when running executables. " +
+			"The profile is only applied in workspaces that define it. Omit the name to clear the current profile.",
+		Args: cobra.MaximumNArgs(1),
+		Run:  func(cmd *cobra.Command, args []string) { setProfileFunc(ctx, cmd, args) },
+	}
+	setCmd.AddCommand(profileCmd)
+}
+
+func setProfileFunc(ctx *context.Context, _ *cobra.Command, args []string) {
+	var profile string
+	if len(args) > 0 {
+		profile = args[0]
+	}
+	userConfig := ctx.Config
+	userConfig.CurrentProfile = profile
+	if err := filesystem.WriteConfig(userConfig); err != nil {
+		logger.Log().FatalErr(err)
+	}
+	if profile == "" {
+		logger.Log().PlainTextSuccess("Profile cleared")
+		return
+	}
+	logger.Log().PlainTextSuccess("Profile set to " + profile)
+}
+
 func registerSetWorkspaceModeCmd(ctx *context.Context, setCmd *cobra.Command) {
 	workspaceModeCmd := &cobra.Command{
 		Use:       "workspace-mode [fixed|dynamic]",
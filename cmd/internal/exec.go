@@ -4,9 +4,12 @@ import (
 	"errors"
 	"fmt"
 	stdio "io"
+	"maps"
 	"os"
 	osExec "os/exec"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -24,11 +27,13 @@ import (
 	"github.com/flowexec/flow/v2/internal/runner"
 	"github.com/flowexec/flow/v2/internal/runner/engine"
 	"github.com/flowexec/flow/v2/internal/runner/exec"
+	"github.com/flowexec/flow/v2/internal/runner/graphql"
 	"github.com/flowexec/flow/v2/internal/runner/launch"
 	"github.com/flowexec/flow/v2/internal/runner/parallel"
 	"github.com/flowexec/flow/v2/internal/runner/render"
 	"github.com/flowexec/flow/v2/internal/runner/request"
 	"github.com/flowexec/flow/v2/internal/runner/serial"
+	"github.com/flowexec/flow/v2/internal/services/rest"
 	"github.com/flowexec/flow/v2/internal/utils/env"
 	"github.com/flowexec/flow/v2/pkg/context"
 	flowErrors "github.com/flowexec/flow/v2/pkg/errors"
@@ -89,6 +94,11 @@ func RegisterExecCmd(ctx *context.Context, rootCmd *cobra.Command) {
 	RegisterFlag(ctx, subCmd, *flags.CmdDirFlag)
 	RegisterFlag(ctx, subCmd, *flags.SpecFlag)
 	RegisterFlag(ctx, subCmd, *flags.RunWorkspaceFlag)
+	RegisterFlag(ctx, subCmd, *flags.ProfileFlag)
+	RegisterFlag(ctx, subCmd, *flags.YesFlag)
+	RegisterFlag(ctx, subCmd, *flags.RecordFlag)
+	RegisterFlag(ctx, subCmd, *flags.ReplayFlag)
+	RegisterFlag(ctx, subCmd, *flags.ReplayMatchFlag)
 	rootCmd.AddCommand(subCmd)
 }
 
@@ -104,15 +114,52 @@ func execPreRun(ctx *context.Context, cmd *cobra.Command, _ []string) {
 		}
 		ctx.SetCurrentWorkspace(res)
 	}
+	if err := setFixtureEnv(cmd); err != nil {
+		errhandler.HandleUsage(ctx, cmd, "%v", err)
+		return
+	}
 
 	runner.RegisterRunner(exec.NewRunner())
 	runner.RegisterRunner(launch.NewRunner())
 	runner.RegisterRunner(request.NewRunner())
+	runner.RegisterRunner(graphql.NewRunner())
 	runner.RegisterRunner(render.NewRunner())
 	runner.RegisterRunner(serial.NewRunner())
 	runner.RegisterRunner(parallel.NewRunner())
 }
 
+// setFixtureEnv passes the --record and --replay flags to the request runner, and to nested flow processes, through
+// the environment.
+func setFixtureEnv(cmd *cobra.Command) error {
+	record := flags.ValueFor[string](cmd, *flags.RecordFlag, false)
+	replay := flags.ValueFor[string](cmd, *flags.ReplayFlag, false)
+	match := flags.ValueFor[[]string](cmd, *flags.ReplayMatchFlag, false)
+	if record != "" && replay != "" {
+		return errors.New("--record and --replay can't be used together")
+	}
+	if record != "" {
+		dir, err := filepath.Abs(record)
+		if err != nil {
+			return err
+		}
+		_ = os.Setenv(request.RecordEnv, dir)
+	}
+	if replay != "" {
+		dir, err := filepath.Abs(replay)
+		if err != nil {
+			return err
+		}
+		_ = os.Setenv(request.ReplayEnv, dir)
+	}
+	if len(match) > 0 {
+		if _, err := rest.ParseMatchFields(strings.Join(match, ",")); err != nil {
+			return err
+		}
+		_ = os.Setenv(request.ReplayMatchEnv, strings.Join(match, ","))
+	}
+	return nil
+}
+
 func execFunc(ctx *context.Context, cmd *cobra.Command, verb executable.Verb, args []string) {
 	logMode := flags.ValueFor[string](cmd, *flags.LogModeFlag, false)
 	if logMode != "" {
@@ -159,12 +206,15 @@ func execFunc(ctx *context.Context, cmd *cobra.Command, verb executable.Verb, ar
 		_ = os.Setenv(store.BucketEnv, ref.String())
 	}
 
-	envMap := buildExecEnv(ctx, cmd, e)
+	envMap, profileArgs := buildExecEnv(ctx, cmd, e)
 
 	var execArgs []string
 	if len(args) >= 2 {
 		execArgs = args[1:]
 	}
+	if len(profileArgs) > 0 {
+		execArgs = append(slices.Clone(profileArgs), execArgs...)
+	}
 
 	startTime := time.Now()
 	prov := runProvenanceFromEnv()
@@ -286,7 +336,7 @@ func execAdHoc(ctx *context.Context, cmd *cobra.Command, verb executable.Verb, c
 }
 
 // execTransientSpec runs a transient executable parsed from an inline definition (--spec). Unlike
-// --cmd, the spec can be any executable type (exec, serial, parallel, request, render, launch); it
+// --cmd, the spec can be any executable type (exec, serial, parallel, request, graphql, render, launch); it
 // is never written to disk but runs through the normal engine and is recorded in history.
 func execTransientSpec(ctx *context.Context, cmd *cobra.Command, verb executable.Verb, spec string) {
 	// A detached background child inherits no stdin, so a spec piped via stdin can't be re-read.
@@ -462,7 +512,7 @@ func runTransientExecutable(
 		_ = os.Setenv(store.BucketEnv, ref.String())
 	}
 
-	envMap := buildExecEnv(ctx, cmd, e)
+	envMap, _ := buildExecEnv(ctx, cmd, e)
 
 	startTime := time.Now()
 	prov := runProvenanceFromEnv()
@@ -678,8 +728,13 @@ func finalizeBackgroundRun(ctx *context.Context, runID string, runErr error) {
 	}
 }
 
-func buildExecEnv(ctx *context.Context, cmd *cobra.Command, e *executable.Executable) map[string]string {
+// buildExecEnv resolves the input environment for a run from the workspace env files, the active profile,
+// --param overrides and any prompted values. The active profile's default args are returned alongside it.
+func buildExecEnv(
+	ctx *context.Context, cmd *cobra.Command, e *executable.Executable,
+) (map[string]string, []string) {
 	envMap := make(map[string]string)
+	var profileArgs []string
 	if wsData, err := ctx.WorkspacesCache.GetWorkspaceConfigList(); err != nil {
 		logger.Log().Errorf("failed to get workspace cache data, skipping env file resolution: %v", err)
 	} else {
@@ -687,6 +742,10 @@ func buildExecEnv(ctx *context.Context, cmd *cobra.Command, e *executable.Execut
 			logger.Log().Warnf("workspace %s not found in cache, skipping env file resolution", e.Workspace())
 		} else {
 			applyWorkspaceParameterOverrides(wsCfg, envMap)
+			if name, profile := resolveProfile(ctx, cmd, wsCfg); profile != nil {
+				applyProfile(ctx, cmd, wsCfg, name, profile, envMap)
+				profileArgs = profile.Args
+			}
 		}
 	}
 
@@ -706,7 +765,101 @@ func buildExecEnv(ctx *context.Context, cmd *cobra.Command, e *executable.Execut
 			envMap[key] = fmt.Sprintf("%v", val)
 		}
 	}
-	return envMap
+	return envMap, profileArgs
+}
+
+// resolveProfile returns the workspace environment profile selected for the run. The --profile flag takes
+// precedence over FLOW_PROFILE and the currentProfile config setting. Only a profile requested with the flag
+// must be defined by the workspace; the others are inherited by nested runs and shared across workspaces,
+// so they are skipped for workspaces that don't define them.
+func resolveProfile(
+	ctx *context.Context, cmd *cobra.Command, ws *workspace.Workspace,
+) (string, *workspace.Profile) {
+	name := flags.ValueFor[string](cmd, *flags.ProfileFlag, false)
+	explicit := name != ""
+	if name == "" {
+		name = os.Getenv(env.ProfileEnvKey)
+	}
+	if name == "" {
+		name = ctx.Config.CurrentProfile
+	}
+	if name == "" {
+		return "", nil
+	}
+
+	profile, found := ws.Profile(name)
+	if !found {
+		if explicit {
+			errhandler.HandleFatal(ctx, cmd, fmt.Errorf(
+				"profile '%s' is not defined in workspace '%s'", name, ws.AssignedName(),
+			))
+		}
+		logger.Log().Debugf("profile %s not defined in workspace %s, skipping", name, ws.AssignedName())
+		return "", nil
+	}
+	return name, profile
+}
+
+// applyProfile layers the profile's env files and params over the workspace environment and switches the
+// vault used to resolve secrets for the rest of the run. Profiles that require confirmation are confirmed
+// before anything is applied.
+func applyProfile(
+	ctx *context.Context,
+	cmd *cobra.Command,
+	ws *workspace.Workspace,
+	name string,
+	profile *workspace.Profile,
+	envMap map[string]string,
+) {
+	if profile.RequireConfirmation && !flags.ValueFor[bool](cmd, *flags.YesFlag, false) {
+		confirmProfile(ctx, cmd, name)
+	}
+
+	if len(profile.EnvFiles) > 0 {
+		loaded, err := env.LoadEnvFromFiles(profile.EnvFiles, ws.Location())
+		if err != nil {
+			logger.Log().Errorf("failed loading env files for profile %s: %v", name, err)
+		}
+		maps.Copy(envMap, loaded)
+	}
+	maps.Copy(envMap, profile.Params)
+
+	if profile.Vault != "" {
+		// The config is never written during a run, so this only affects secret resolution for this process.
+		vaultName := profile.Vault
+		ctx.Config.CurrentVault = &vaultName
+	}
+	ctx.ActiveProfile = name
+	logger.Log().Debugf("applied profile %s from workspace %s", name, ws.AssignedName())
+}
+
+func confirmProfile(ctx *context.Context, cmd *cobra.Command, name string) {
+	// A detached background child has no terminal to prompt on.
+	if os.Getenv(backgroundRunIDEnv) != "" {
+		errhandler.HandleFatal(ctx, cmd, fmt.Errorf(
+			"profile '%s' requires confirmation; use --yes to run it in the background", name,
+		))
+	}
+
+	form, err := views.NewForm(
+		logger.Theme(ctx.Config.Theme.String()),
+		ctx.StdIn(),
+		ctx.StdOut(),
+		&views.FormField{
+			Key:   "confirm",
+			Type:  views.PromptTypeConfirm,
+			Title: fmt.Sprintf("The '%s' profile requires confirmation. Are you sure you want to continue?", name),
+		})
+	if err != nil {
+		errhandler.HandleFatal(ctx, cmd, err)
+	}
+	if err := form.Run(ctx); err != nil {
+		errhandler.HandleFatal(ctx, cmd, err)
+	}
+	resp := form.FindByKey("confirm").Value()
+	if truthy, _ := strconv.ParseBool(resp); !truthy {
+		errhandler.HandleFatal(ctx, cmd, fmt.Errorf("run with profile '%s' was not confirmed", name))
+	}
 }
 
 func cleanupProcessStore(ctx *context.Context) {
@@ -981,6 +1134,13 @@ var (
 
   # Pass flag and positional arguments to the executable
   flow exec ws/ns:build -- --flag1=value1 --flag2=value2 value3 value4
+
+  # Execute with the workspace's 'prod' environment profile
+  flow exec deploy --profile prod
+
+  # Record the requests of a flow, then replay their responses without the network
+  flow exec sync-users --record ./fixtures
+  flow exec sync-users --replay ./fixtures
 `
 )
 
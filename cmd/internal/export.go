@@ -0,0 +1,106 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	errhandler "github.com/flowexec/flow/v2/cmd/internal/errors"
+	"github.com/flowexec/flow/v2/cmd/internal/flags"
+	"github.com/flowexec/flow/v2/cmd/internal/response"
+	"github.com/flowexec/flow/v2/internal/exporter"
+	"github.com/flowexec/flow/v2/pkg/context"
+	"github.com/flowexec/flow/v2/pkg/logger"
+	"github.com/flowexec/flow/v2/types/common"
+	"github.com/flowexec/flow/v2/types/executable"
+)
+
+func RegisterExportCmd(ctx *context.Context, rootCmd *cobra.Command) {
+	exportCmd := &cobra.Command{
+		Use:       fmt.Sprintf("export [%s]", strings.Join(exporter.Formats(), "|")),
+		Short:     "Export a workspace's executables to a Makefile, justfile or CI workflow.",
+		Long:      exportLong,
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: exporter.Formats(),
+		Run:       func(cmd *cobra.Command, args []string) { exportFunc(ctx, cmd, args) },
+	}
+	RegisterFlag(ctx, exportCmd, *flags.FilterWorkspaceFlag)
+	RegisterFlag(ctx, exportCmd, *flags.FilterNamespaceFlag)
+	RegisterFlag(ctx, exportCmd, *flags.ExportFileFlag)
+	RegisterFlag(ctx, exportCmd, *flags.OutputFormatFlag)
+	rootCmd.AddCommand(exportCmd)
+}
+
+func exportFunc(ctx *context.Context, cmd *cobra.Command, args []string) {
+	format := exporter.Format(strings.ToLower(args[0]))
+	if !slices.Contains(exporter.Formats(), string(format)) {
+		errhandler.HandleUsage(ctx, cmd, "invalid format %q; must be one of: %s",
+			args[0], strings.Join(exporter.Formats(), ", "))
+	}
+
+	wsFilter := flags.ValueFor[string](cmd, *flags.FilterWorkspaceFlag, false)
+	if wsFilter == "" || wsFilter == "." {
+		wsFilter = ctx.CurrentWorkspaceName()
+	}
+	nsFilter := flags.ValueFor[string](cmd, *flags.FilterNamespaceFlag, false)
+	switch nsFilter {
+	case "":
+		nsFilter = executable.WildcardNamespace
+	case ".":
+		nsFilter = ctx.Config.CurrentNamespace
+	}
+
+	allExecs, err := ctx.ExecutableCache.GetExecutableList()
+	if err != nil {
+		errhandler.HandleFatal(ctx, cmd, err)
+	}
+	// Internal executables are included since the exported ones may reference them.
+	execs := allExecs.
+		FilterByWorkspaceWithVisibility(wsFilter, common.VisibilityInternal).
+		FilterByNamespace(nsFilter)
+	if len(execs) == 0 {
+		errhandler.HandleFatal(ctx, cmd, fmt.Errorf("no executables found in workspace %s", wsFilter))
+	}
+
+	result, err := exporter.Export(format, execs)
+	if err != nil {
+		errhandler.HandleFatal(ctx, cmd, err)
+	}
+
+	file := flags.ValueFor[string](cmd, *flags.ExportFileFlag, false)
+	if file == "" {
+		if _, err := fmt.Fprint(ctx.StdOut(), result.Content); err != nil {
+			errhandler.HandleFatal(ctx, cmd, err)
+		}
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(file), 0750); err != nil {
+		errhandler.HandleFatal(ctx, cmd, fmt.Errorf("unable to create the directory of %s: %w", file, err))
+	}
+	if err := os.WriteFile(file, []byte(result.Content), 0600); err != nil {
+		errhandler.HandleFatal(ctx, cmd, fmt.Errorf("unable to write %s: %w", file, err))
+	}
+	issues := make([]string, 0, len(result.Issues))
+	for _, i := range result.Issues {
+		issues = append(issues, i.String())
+		logger.Log().PlainTextWarn(i.String())
+	}
+	response.HandleSuccess(ctx, cmd, fmt.Sprintf("Exported %d executable(s) to %s", len(execs), file), map[string]any{
+		"file":   file,
+		"format": string(format),
+		"issues": issues,
+	})
+}
+
+const exportLong = `Export the executables of a workspace to a Makefile, a justfile, a GitHub Actions workflow or a
+GitLab CI pipeline, so that they can be run where flow isn't installed.
+
+Serial executables become sequential recipes or steps, parallel executables become make -j friendly
+prerequisites or parallel jobs, and args become variables. Constructs that can't be expressed in the
+format (secrets, prompts, conditions, containers, ...) are flagged with "flow export:" comments and
+listed as warnings. The exported file is printed to stdout unless --file is set.`
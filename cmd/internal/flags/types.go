@@ -360,6 +360,14 @@ var RunWorkspaceFlag = &Metadata{
 	Required: false,
 }
 
+var ProfileFlag = &Metadata{
+	Name: "profile",
+	Usage: "Workspace environment profile to apply to the run (e.g. dev, staging, prod). " +
+		"Overrides the FLOW_PROFILE environment variable and the currentProfile config setting.",
+	Default:  "",
+	Required: false,
+}
+
 var LabelFlag = &Metadata{
 	Name:     "label",
 	Usage:    "A short, human-readable label for an ad-hoc command (used in history). Only valid with --cmd.",
@@ -377,12 +385,37 @@ var CmdDirFlag = &Metadata{
 var SpecFlag = &Metadata{
 	Name: "spec",
 	Usage: "Run a transient executable from an inline definition (any type: exec, serial, parallel, request, " +
-		"render, launch). Accepts inline YAML/JSON, '@path' to read a file, or '-' to read stdin. " +
+		"graphql, render, launch). Accepts inline YAML/JSON, '@path' to read a file, or '-' to read stdin. " +
 		"The executable is not saved to disk but is recorded in `flow logs`.",
 	Default:  "",
 	Required: false,
 }
 
+var RecordFlag = &Metadata{
+	Name: "record",
+	Usage: "Save each request made by request and graphql executables, and its response, as a fixture in the " +
+		"given directory.",
+	Default:  "",
+	Required: false,
+}
+
+var ReplayFlag = &Metadata{
+	Name: "replay",
+	Usage: "Respond to the requests of request and graphql executables with matching fixtures from the given " +
+		"directory instead of sending them. Requests without a matching fixture fail. " +
+		"Can also be set with the FLOW_REQUEST_REPLAY environment variable.",
+	Default:  "",
+	Required: false,
+}
+
+var ReplayMatchFlag = &Metadata{
+	Name: "replay-match",
+	Usage: "Request fields that a fixture must match to be replayed (method, url, body). Defaults to all of them. " +
+		"Can also be set with the FLOW_REQUEST_REPLAY_MATCH environment variable.",
+	Default:  []string{},
+	Required: false,
+}
+
 var ParameterValueFlag = &Metadata{
 	Name:      "param",
 	Shorthand: "p",
@@ -498,3 +531,9 @@ var GlobalCacheFlag = &Metadata{
 	Default:   false,
 	Required:  false,
 }
+
+var ExportFileFlag = &Metadata{
+	Name:    "file",
+	Usage:   "Path to write the exported file to. Defaults to printing it to stdout.",
+	Default: "",
+}
@@ -119,6 +119,7 @@ func RegisterSubCommands(ctx *context.Context, rootCmd *cobra.Command) {
 	internal.RegisterLogsCmd(ctx, rootCmd)
 	internal.RegisterSyncCmd(ctx, rootCmd)
 	internal.RegisterSchemaCmd(ctx, rootCmd)
+	internal.RegisterExportCmd(ctx, rootCmd)
 	internal.RegisterMCPCmd(ctx, rootCmd)
 	internal.RegisterCliCmd(ctx, rootCmd)
 }
@@ -0,0 +1,329 @@
+package exporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/flowexec/flow/v2/internal/utils"
+	"github.com/flowexec/flow/v2/types/executable"
+)
+
+// ciJob is a task as a CI job. The tasks that it references are inlined into its steps, since CI jobs can't run other
+// jobs.
+type ciJob struct {
+	id    string
+	t     *task
+	env   [][2]string
+	steps []ciStep
+	needs []string
+	// parallelStep is true for the jobs that run the steps of a parallel task.
+	parallelStep bool
+}
+
+type ciStep struct {
+	name string
+	dir  string
+	// setup holds the lines that set up the args and params of the task that the step belongs to.
+	setup         []string
+	cmd           []string
+	ignoreFailure bool
+}
+
+var invalidJobIDChars = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// ciJobs converts the tasks into CI jobs. Parallel tasks are split into a job per step and a job that needs all of
+// them, so that the CI system runs the steps in parallel. missingArg returns the value of a job's required arg that
+// has no default, which has to be configured in the CI system.
+func (x *exporter) ciJobs(missingArg func(key string) string) []*ciJob {
+	jobs := make([]*ciJob, 0, len(x.tasks))
+	for _, t := range x.tasks {
+		job := &ciJob{id: jobID(t.name), t: t}
+		for _, a := range t.args {
+			switch {
+			case a.EnvKey == "":
+			case a.Required && a.Default == "":
+				job.env = append(job.env, [2]string{a.EnvKey, missingArg(a.EnvKey)})
+			default:
+				job.env = append(job.env, [2]string{a.EnvKey, a.Default})
+			}
+		}
+
+		if t.kind != kindParallel {
+			job.steps = x.ciSteps(t, t, nil, false, map[*task]bool{})
+			jobs = append(jobs, job)
+			continue
+		}
+		for i, s := range t.steps {
+			if s.skipped != "" {
+				continue
+			}
+			child := &ciJob{id: fmt.Sprintf("%s-%d", job.id, i+1), t: t, env: job.env, parallelStep: true}
+			child.steps = x.ciStep(t, t, s, t.env, map[*task]bool{t: true})
+			job.needs = append(job.needs, child.id)
+			jobs = append(jobs, child)
+		}
+		done := fmt.Sprintf("echo %s", utils.ShellQuote("All steps of "+t.name+" passed"))
+		job.steps = []ciStep{{name: t.name, cmd: []string{done}}}
+		jobs = append(jobs, job)
+	}
+	return jobs
+}
+
+// ciSteps returns the steps of a task, inlining the tasks that it references. The args of inlined tasks are set in
+// their steps, from the values given to them or their defaults.
+func (x *exporter) ciSteps(root, t *task, args [][2]string, inlined bool, seen map[*task]bool) []ciStep {
+	if seen[t] {
+		x.flag(root.e, "%s references itself; the reference isn't inlined", t.e.Ref())
+		return nil
+	}
+	seen[t] = true
+	defer delete(seen, t)
+
+	var setup []string
+	if inlined {
+		setup = inlinedArgs(t, args)
+		if t.kind == kindParallel {
+			x.flag(root.e, "the parallel steps of %s run one at a time when they're inlined", t.e.Ref())
+		}
+	}
+	setup = append(setup, t.env...)
+
+	var steps []ciStep
+	for _, s := range t.steps {
+		if s.skipped != "" {
+			continue
+		}
+		steps = append(steps, x.ciStep(root, t, s, setup, seen)...)
+	}
+	return steps
+}
+
+func (x *exporter) ciStep(root, t *task, s step, setup []string, seen map[*task]bool) []ciStep {
+	if s.ref == nil {
+		return []ciStep{{name: t.name, dir: t.dir, setup: setup, cmd: cmdLines(s.cmd), ignoreFailure: s.ignoreFailure}}
+	}
+	steps := x.ciSteps(root, s.ref, s.refArgs, true, seen)
+	for i := range steps {
+		steps[i].ignoreFailure = steps[i].ignoreFailure || s.ignoreFailure
+	}
+	return steps
+}
+
+// inlinedArgs returns the shell lines that set the args of an inlined task.
+func inlinedArgs(t *task, values [][2]string) []string {
+	var lines []string
+	for _, a := range t.args {
+		if a.EnvKey == "" {
+			continue
+		}
+		value, given := a.Default, false
+		for _, kv := range values {
+			if kv[0] == a.EnvKey {
+				value, given = kv[1], true
+			}
+		}
+		if a.Required && !given && a.Default == "" {
+			lines = append(lines, requireEnv(a.EnvKey, "set "+a.EnvKey))
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("export %s=%s", a.EnvKey, utils.ShellQuote(value)))
+	}
+	return lines
+}
+
+// secretParams returns the env keys of the params that flow reads from secrets, for a task and the tasks that it
+// references.
+func secretParams(root *task) []string {
+	var keys []string
+	seen := make(map[string]bool)
+	var visit func(t *task)
+	visit = func(t *task) {
+		if seen[t.name] {
+			return
+		}
+		seen[t.name] = true
+		for _, p := range paramsOf(t.e) {
+			if p.SecretRef != "" && p.EnvKey != "" && !seen["$"+p.EnvKey] {
+				seen["$"+p.EnvKey] = true
+				keys = append(keys, p.EnvKey)
+			}
+		}
+		for _, s := range t.steps {
+			if s.ref != nil {
+				visit(s.ref)
+			}
+		}
+	}
+	visit(root)
+	return keys
+}
+
+// githubActions renders the tasks as the jobs of a GitHub Actions workflow that runs on push and manually.
+func (x *exporter) githubActions() string {
+	jobs := x.ciJobs(func(key string) string {
+		return fmt.Sprintf("${{ vars.%s }}", key)
+	})
+	for _, job := range jobs {
+		for _, a := range job.t.args {
+			if a.EnvKey != "" && a.Required && a.Default == "" && !job.parallelStep {
+				x.flag(job.t.e, "arg %s is required; it's read from the repository variable %s", a.EnvKey, a.EnvKey)
+			}
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("# Generated by `flow export github-actions`.\n")
+	fmt.Fprintf(&b, "# Constructs that can't be expressed in GitHub Actions are flagged with %q comments.\n", issueMarker)
+	b.WriteString("name: flow\n\non:\n  push:\n  workflow_dispatch:\n\njobs:\n")
+	for _, job := range jobs {
+		b.WriteString("  " + job.id + ":\n")
+		if !job.parallelStep {
+			for _, msg := range x.issuesFor(job.t.e) {
+				fmt.Fprintf(&b, "    # %s %s\n", issueMarker, msg)
+			}
+		}
+		if desc := description(job.t.e); desc != "" {
+			fmt.Fprintf(&b, "    name: %s\n", yamlString(desc))
+		}
+		b.WriteString("    runs-on: ubuntu-latest\n")
+		if len(job.needs) > 0 {
+			fmt.Fprintf(&b, "    needs: [%s]\n", strings.Join(job.needs, ", "))
+		}
+		env := job.env
+		for _, key := range secretParams(job.t) {
+			env = append(env, [2]string{key, fmt.Sprintf("${{ secrets.%s }}", key)})
+		}
+		if len(env) > 0 {
+			b.WriteString("    env:\n")
+			for _, kv := range env {
+				fmt.Fprintf(&b, "      %s: %s\n", kv[0], yamlString(kv[1]))
+			}
+		}
+		b.WriteString("    steps:\n      - uses: actions/checkout@v4\n")
+		for _, s := range job.steps {
+			fmt.Fprintf(&b, "      - name: %s\n", yamlString(s.name))
+			if s.dir != "" {
+				fmt.Fprintf(&b, "        working-directory: %s\n", yamlString(s.dir))
+			}
+			if s.ignoreFailure {
+				b.WriteString("        continue-on-error: true\n")
+			}
+			b.WriteString("        run: |\n")
+			for _, l := range slices.Concat(s.setup, s.cmd) {
+				// GitHub evaluates expressions in scripts before they're run.
+				fmt.Fprintf(&b, "          %s\n", strings.ReplaceAll(l, "${{", "${{ '${{' }}"))
+			}
+		}
+	}
+	return b.String()
+}
+
+// gitlabCI renders the tasks as the jobs of a GitLab CI pipeline.
+func (x *exporter) gitlabCI() string {
+	jobs := x.ciJobs(func(string) string { return "" })
+	for _, job := range jobs {
+		for _, a := range job.t.args {
+			if a.EnvKey != "" && a.Required && a.Default == "" && !job.parallelStep {
+				x.flag(job.t.e, "arg %s is required; it must be set as a CI/CD variable", a.EnvKey)
+			}
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("# Generated by `flow export gitlab-ci`.\n")
+	fmt.Fprintf(&b, "# Constructs that can't be expressed in GitLab CI are flagged with %q comments.\n", issueMarker)
+	for _, job := range jobs {
+		b.WriteString("\n")
+		if !job.parallelStep {
+			for _, msg := range x.issuesFor(job.t.e) {
+				fmt.Fprintf(&b, "# %s %s\n", issueMarker, msg)
+			}
+		}
+		if desc := description(job.t.e); desc != "" {
+			fmt.Fprintf(&b, "# %s\n", desc)
+		}
+		b.WriteString(job.id + ":\n")
+		if len(job.needs) > 0 {
+			fmt.Fprintf(&b, "  needs: [%s]\n", strings.Join(job.needs, ", "))
+		}
+		var vars [][2]string
+		for _, kv := range job.env {
+			// Required args without a default are left to the CI/CD variables.
+			if kv[1] != "" || !isRequiredArg(job.t, kv[0]) {
+				vars = append(vars, kv)
+			}
+		}
+		if len(vars) > 0 {
+			b.WriteString("  variables:\n")
+			for _, kv := range vars {
+				fmt.Fprintf(&b, "    %s: %s\n", kv[0], yamlString(strings.ReplaceAll(kv[1], "$", "$$")))
+			}
+		}
+		b.WriteString("  script:\n")
+		for _, s := range job.steps {
+			b.WriteString("    - |\n")
+			for _, l := range gitlabScript(s) {
+				fmt.Fprintf(&b, "      %s\n", l)
+			}
+		}
+	}
+	return b.String()
+}
+
+// gitlabScript returns the lines of a step's script. The script entries of a job share a shell, so steps that change
+// directory or set variables run in a subshell.
+func gitlabScript(s ciStep) []string {
+	var setup []string
+	if s.dir != "" {
+		setup = append(setup, fmt.Sprintf(`cd "$CI_PROJECT_DIR"/%s`, utils.ShellQuote(s.dir)))
+	}
+	setup = append(setup, s.setup...)
+	if len(setup) == 0 && !s.ignoreFailure {
+		return s.cmd
+	}
+	lines := []string{"("}
+	for _, l := range append(setup, s.cmd...) {
+		lines = append(lines, "  "+l)
+	}
+	if s.ignoreFailure {
+		return append(lines, ") || true")
+	}
+	return append(lines, ")")
+}
+
+func isRequiredArg(t *task, key string) bool {
+	for _, a := range t.args {
+		if a.EnvKey == key {
+			return a.Required && a.Default == ""
+		}
+	}
+	return false
+}
+
+func paramsOf(e *executable.Executable) executable.ParameterList {
+	switch {
+	case e.Exec != nil:
+		return e.Exec.Params
+	case e.Serial != nil:
+		return e.Serial.Params
+	case e.Parallel != nil:
+		return e.Parallel.Params
+	}
+	return nil
+}
+
+func jobID(name string) string {
+	return strings.Trim(invalidJobIDChars.ReplaceAllString(name, "-"), "-")
+}
+
+// yamlString quotes s as a YAML (and JSON) string.
+func yamlString(s string) string {
+	var b strings.Builder
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	return strings.TrimSuffix(b.String(), "\n")
+}
@@ -0,0 +1,435 @@
+// Package exporter converts executables into the task runner and CI formats used by people who don't have flow
+// installed: Makefiles, justfiles, GitHub Actions workflows and GitLab CI pipelines.
+package exporter
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/flowexec/flow/v2/internal/utils"
+	"github.com/flowexec/flow/v2/pkg/context"
+	"github.com/flowexec/flow/v2/types/executable"
+)
+
+// Format is a file format that executables can be exported to.
+type Format string
+
+const (
+	FormatMakefile      Format = "makefile"
+	FormatJustfile      Format = "justfile"
+	FormatGitHubActions Format = "github-actions"
+	FormatGitLabCI      Format = "gitlab-ci"
+)
+
+// issueMarker prefixes the comments that flag constructs which can't be expressed in the exported file.
+const issueMarker = "flow export:"
+
+// Formats returns the names of the supported export formats.
+func Formats() []string {
+	return []string{string(FormatMakefile), string(FormatJustfile), string(FormatGitHubActions), string(FormatGitLabCI)}
+}
+
+// Issue is a construct of an executable that can't be expressed in the export format.
+type Issue struct {
+	Ref     string `json:"ref"     yaml:"ref"`
+	Message string `json:"message" yaml:"message"`
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("%s: %s", i.Ref, i.Message)
+}
+
+// Result is an exported file, along with the constructs that couldn't be expressed in it. The issues are also flagged
+// with comments in the file's content.
+type Result struct {
+	Content string
+	Issues  []Issue
+}
+
+// Export converts the executables of a workspace into the given format. Paths in the exported file are relative to
+// the workspace's root directory.
+func Export(format Format, execs executable.ExecutableList) (*Result, error) {
+	x := newExporter(execs)
+	var content string
+	switch format {
+	case FormatMakefile:
+		content = x.makefile()
+	case FormatJustfile:
+		content = x.justfile()
+	case FormatGitHubActions:
+		content = x.githubActions()
+	case FormatGitLabCI:
+		content = x.gitlabCI()
+	default:
+		return nil, fmt.Errorf("unsupported export format %q (%s)", format, strings.Join(Formats(), ", "))
+	}
+	return &Result{Content: content, Issues: x.issues}, nil
+}
+
+type taskKind int
+
+const (
+	kindExec taskKind = iota
+	kindSerial
+	kindParallel
+)
+
+// task is an executable as it's exported: a target, recipe or job.
+type task struct {
+	e    *executable.Executable
+	name string
+	kind taskKind
+	args executable.ArgumentList
+	// env holds the shell lines that set up the task's params.
+	env   []string
+	dir   string
+	steps []step
+}
+
+// step is a command or a reference to another task, run as part of a task.
+type step struct {
+	cmd string
+	ref *task
+	// refArgs are the values given to the referenced task's args, by env key.
+	refArgs [][2]string
+	// skipped is the reason that the step is left out of the exported file.
+	skipped       string
+	ignoreFailure bool
+}
+
+type exporter struct {
+	tasks  []*task
+	byRef  map[executable.Ref]*task
+	issues []Issue
+}
+
+func newExporter(execs executable.ExecutableList) *exporter {
+	x := &exporter{byRef: make(map[executable.Ref]*task)}
+	sorted := slices.Clone(execs)
+	slices.SortFunc(sorted, func(a, b *executable.Executable) int {
+		return strings.Compare(a.Ref().String(), b.Ref().String())
+	})
+
+	var exported executable.ExecutableList
+	counts := make(map[string]int)
+	for _, e := range sorted {
+		if _, ok := taskKindOf(e); !ok {
+			x.flag(e, "only exec, serial and parallel executables can be exported; skipped")
+			continue
+		}
+		exported = append(exported, e)
+		counts[taskName(e, false)]++
+	}
+
+	names := make(map[string]bool)
+	for _, e := range exported {
+		kind, _ := taskKindOf(e)
+		base := taskName(e, counts[taskName(e, false)] > 1)
+		name := base
+		for i := 2; names[name]; i++ {
+			name = fmt.Sprintf("%s-%d", base, i)
+		}
+		names[name] = true
+		t := &task{e: e, name: name, kind: kind}
+		x.tasks = append(x.tasks, t)
+		x.byRef[e.Ref()] = t
+	}
+	for _, t := range x.tasks {
+		x.build(t)
+	}
+	return x
+}
+
+func taskKindOf(e *executable.Executable) (taskKind, bool) {
+	switch {
+	case e.Exec != nil:
+		return kindExec, true
+	case e.Serial != nil:
+		return kindSerial, true
+	case e.Parallel != nil:
+		return kindParallel, true
+	}
+	return 0, false
+}
+
+// taskName returns the name of an executable's target or job, e.g. `build-app`. The namespace is included when
+// executables in different namespaces would have the same name, e.g. `test-tools-lint`.
+func taskName(e *executable.Executable, withNamespace bool) string {
+	parts := []string{e.Verb.String()}
+	if ns := e.Namespace(); ns != "" && withNamespace {
+		parts = append(parts, strings.ReplaceAll(ns, ".", "-"))
+	}
+	if e.Name != "" {
+		parts = append(parts, e.Name)
+	}
+	return strings.Join(parts, "-")
+}
+
+func (x *exporter) flag(e *executable.Executable, format string, a ...any) string {
+	msg := fmt.Sprintf(format, a...)
+	x.issues = append(x.issues, Issue{Ref: e.Ref().String(), Message: msg})
+	return msg
+}
+
+//nolint:gocognit
+func (x *exporter) build(t *task) {
+	e := t.e
+	switch t.kind {
+	case kindExec:
+		spec := e.Exec
+		t.args, t.dir = spec.Args, x.dir(e, spec.Dir)
+		t.env = x.env(e, spec.Params)
+		if spec.Container != nil {
+			x.flag(e, "runs on the host instead of in a container")
+		}
+		cmd := spec.Cmd
+		if spec.File != "" {
+			cmd = fileCmd(spec.File)
+		}
+		t.steps = []step{{cmd: cmd}}
+	case kindSerial:
+		spec := e.Serial
+		t.args, t.dir = spec.Args, x.dir(e, spec.Dir)
+		t.env = x.env(e, spec.Params)
+		ignoreFailure := spec.FailFast != nil && !*spec.FailFast
+		for _, s := range spec.Execs {
+			st := x.step(e, s.Cmd, s.Ref, s.Args, s.If, s.Retries, s.ReviewRequired)
+			st.ignoreFailure = ignoreFailure
+			t.steps = append(t.steps, st)
+		}
+	case kindParallel:
+		spec := e.Parallel
+		t.args, t.dir = spec.Args, x.dir(e, spec.Dir)
+		t.env = x.env(e, spec.Params)
+		ignoreFailure := spec.FailFast != nil && !*spec.FailFast
+		for _, s := range spec.Execs {
+			st := x.step(e, s.Cmd, s.Ref, s.Args, s.If, s.Retries, false)
+			st.ignoreFailure = ignoreFailure
+			t.steps = append(t.steps, st)
+		}
+	}
+	for _, a := range t.args {
+		if a.OutputFile != "" {
+			x.flag(e, "arg %s is written to %s by flow; it's passed as a variable instead", argName(a), a.OutputFile)
+		}
+	}
+}
+
+func (x *exporter) step(
+	e *executable.Executable, cmd string, ref executable.Ref, args []string, cond string, retries int, review bool,
+) step {
+	if cond != "" {
+		return step{skipped: x.flag(e, "step with condition `%s` can't be expressed and is skipped", cond)}
+	}
+	if retries > 0 {
+		x.flag(e, "step retries (%d) can't be expressed and are ignored", retries)
+	}
+	if review {
+		x.flag(e, "step review prompts can't be expressed and are ignored")
+	}
+	if ref == "" {
+		return step{cmd: cmd}
+	}
+
+	target := x.lookup(context.ExpandRefFromParent(e, ref))
+	if target == nil {
+		return step{skipped: x.flag(e, "referenced executable %s isn't exported; the step is skipped", ref)}
+	}
+	return step{ref: target, refArgs: x.refArgs(e, target, args)}
+}
+
+// lookup returns the task of a referenced executable, matching verb aliases like flow does.
+func (x *exporter) lookup(ref executable.Ref) *task {
+	if t, found := x.byRef[ref]; found {
+		return t
+	}
+	for _, t := range x.tasks {
+		if t.e.ID() == ref.ID() && t.e.Verb.Equals(ref.Verb()) {
+			return t
+		}
+	}
+	return nil
+}
+
+// refArgs maps the arguments given to a referenced executable (e.g. `--env=prod`, `v1.2.0`) to its args' env keys.
+func (x *exporter) refArgs(e *executable.Executable, target *task, args []string) [][2]string {
+	var values [][2]string
+	var pos int
+	targetArgs := argsOf(target.e)
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		var found *executable.Argument
+		var value string
+		if flag, ok := strings.CutPrefix(arg, "--"); ok {
+			name, v, hasValue := strings.Cut(flag, "=")
+			for j := range targetArgs {
+				if targetArgs[j].Flag == name {
+					found = &targetArgs[j]
+				}
+			}
+			switch {
+			case found == nil:
+			case hasValue:
+				value = v
+			case found.Type == executable.ArgumentTypeBool:
+				value = "true"
+			case i+1 < len(args):
+				i++
+				value = args[i]
+			}
+		} else {
+			pos++
+			for j := range targetArgs {
+				if targetArgs[j].Pos != nil && *targetArgs[j].Pos == pos {
+					found = &targetArgs[j]
+				}
+			}
+			value = arg
+		}
+		if found == nil || found.EnvKey == "" {
+			x.flag(e, "argument %q for %s doesn't match any of its args and is ignored", arg, target.e.Ref())
+			continue
+		}
+		values = append(values, [2]string{found.EnvKey, value})
+	}
+	return values
+}
+
+// env returns the shell lines that set up the params of an executable. Params that flow resolves from secrets or
+// prompts are required to be set in the environment instead.
+func (x *exporter) env(e *executable.Executable, params executable.ParameterList) []string {
+	var lines []string
+	for _, p := range params {
+		switch {
+		case p.SecretRef != "" && p.EnvKey != "":
+			x.flag(e, "param %s is read from secret %s; it must be set in the environment", p.EnvKey, p.SecretRef)
+			lines = append(lines, requireEnv(p.EnvKey, fmt.Sprintf("set %s to the value of secret %s", p.EnvKey, p.SecretRef)))
+		case p.Prompt != "" && p.EnvKey != "":
+			x.flag(e, "param %s is prompted for; it must be set in the environment", p.EnvKey)
+			lines = append(lines, requireEnv(p.EnvKey, p.Prompt))
+		case p.EnvFile != "":
+			lines = append(lines, fmt.Sprintf("set -a; . %s; set +a", utils.ShellQuote(x.path(e, p.EnvFile))))
+		case p.EnvKey != "":
+			lines = append(lines, fmt.Sprintf("export %s=%s", p.EnvKey, utils.ShellQuote(p.Text)))
+		}
+	}
+	return lines
+}
+
+// dir returns an executable's working directory relative to the workspace root, or "" for the root itself.
+func (x *exporter) dir(e *executable.Executable, dir executable.Directory) string {
+	switch d := string(dir); d {
+	case executable.TmpDirLabel:
+		x.flag(e, "temporary directories can't be expressed; it runs from the workspace root")
+		return ""
+	default:
+		return x.path(e, d)
+	}
+}
+
+// path returns a path of an executable relative to the workspace root. Paths are relative to the executable's flow
+// file unless they start with `//` (the workspace root) or `./` (the directory that flow is run from, which is
+// expected to be the workspace root).
+func (x *exporter) path(e *executable.Executable, p string) string {
+	var rel string
+	switch {
+	case strings.HasPrefix(p, "//"):
+		rel = strings.TrimPrefix(p, "//")
+	case p == "." || strings.HasPrefix(p, "./"):
+		rel = p
+	case strings.HasPrefix(p, "~/"):
+		return "$HOME/" + strings.TrimPrefix(p, "~/")
+	case filepath.IsAbs(p) || strings.HasPrefix(p, "$"):
+		return p
+	case e.FlowFilePath() == "" || e.WorkspacePath() == "":
+		rel = p
+	default:
+		abs := filepath.Join(filepath.Dir(e.FlowFilePath()), p)
+		r, err := filepath.Rel(e.WorkspacePath(), abs)
+		if err != nil {
+			return abs
+		}
+		rel = r
+	}
+	rel = filepath.ToSlash(filepath.Clean(rel))
+	if rel == "." {
+		return ""
+	}
+	return rel
+}
+
+// setupLines returns the shell lines that change to a task's directory and set up its params.
+func setupLines(t *task) []string {
+	var lines []string
+	if t.dir != "" {
+		lines = append(lines, "cd "+utils.ShellQuote(t.dir))
+	}
+	return append(lines, t.env...)
+}
+
+// fileCmd returns the command that runs a script file with the interpreter that flow would use for it.
+func fileCmd(file string) string {
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".sh":
+		return "sh " + utils.ShellQuote(file)
+	case ".ps1":
+		return "pwsh -File " + utils.ShellQuote(file)
+	case ".bat", ".cmd":
+		return "cmd /C " + utils.ShellQuote(file)
+	default:
+		return "./" + strings.TrimPrefix(file, "./")
+	}
+}
+
+func requireEnv(key, msg string) string {
+	return fmt.Sprintf(`: "${%s:?%s}"`, key, strings.NewReplacer(`"`, "'", "}", ")", "$", "").Replace(msg))
+}
+
+func argsOf(e *executable.Executable) executable.ArgumentList {
+	switch {
+	case e.Exec != nil:
+		return e.Exec.Args
+	case e.Serial != nil:
+		return e.Serial.Args
+	case e.Parallel != nil:
+		return e.Parallel.Args
+	}
+	return nil
+}
+
+func argName(a executable.Argument) string {
+	if a.EnvKey != "" {
+		return a.EnvKey
+	}
+	return a.Flag
+}
+
+// issuesFor returns the issues flagged for an executable.
+func (x *exporter) issuesFor(e *executable.Executable) []string {
+	var msgs []string
+	for _, i := range x.issues {
+		if i.Ref == e.Ref().String() {
+			msgs = append(msgs, i.Message)
+		}
+	}
+	return msgs
+}
+
+// cmdLines returns the non-empty lines of a command.
+func cmdLines(cmd string) []string {
+	var lines []string
+	for _, l := range strings.Split(strings.TrimSpace(cmd), "\n") {
+		if strings.TrimSpace(l) != "" {
+			lines = append(lines, strings.TrimRight(l, " \t\r"))
+		}
+	}
+	return lines
+}
+
+// description returns the first line of an executable's description.
+func description(e *executable.Executable) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(e.Description), "\n")
+	return strings.TrimSpace(line)
+}
@@ -0,0 +1,157 @@
+package exporter_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/flowexec/flow/v2/internal/exporter"
+	"github.com/flowexec/flow/v2/pkg/filesystem"
+	"github.com/flowexec/flow/v2/types/executable"
+)
+
+func TestExporter(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Exporter Suite")
+}
+
+var _ = Describe("Export", func() {
+	var execs executable.ExecutableList
+
+	BeforeEach(func() {
+		path, err := filepath.Abs("testdata/app/app.flow")
+		Expect(err).NotTo(HaveOccurred())
+		ff, err := filesystem.LoadFlowFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		ff.SetDefaults()
+		ff.SetContext("ws", filepath.Dir(filepath.Dir(path)), path)
+		execs = ff.Executables
+	})
+
+	export := func(format exporter.Format) *exporter.Result {
+		result, err := exporter.Export(format, execs)
+		Expect(err).NotTo(HaveOccurred())
+		return result
+	}
+
+	issues := func(result *exporter.Result) []string {
+		msgs := make([]string, 0, len(result.Issues))
+		for _, i := range result.Issues {
+			msgs = append(msgs, i.String())
+		}
+		return msgs
+	}
+
+	It("should flag the constructs that can't be expressed", func() {
+		result := export(exporter.FormatMakefile)
+		Expect(issues(result)).To(ConsistOf(
+			"open ws/app:docs: only exec, serial and parallel executables can be exported; skipped",
+			"build ws/app:app: param TOKEN is read from secret api-token; it must be set in the environment",
+			"lint ws/app:app: param CONFIRM is prompted for; it must be set in the environment",
+			"release ws/app:app: step with condition `env[\"CI\"] == \"true\"` can't be expressed and is skipped",
+			"release ws/app:app: referenced executable open app:docs isn't exported; the step is skipped",
+		))
+		Expect(result.Content).To(ContainSubstring(
+			"# flow export: param CONFIRM is prompted for; it must be set in the environment\nlint-app:\n",
+		))
+	})
+
+	It("should export a Makefile", func() {
+		content := export(exporter.FormatMakefile).Content
+		Expect(content).To(ContainSubstring(".PHONY: build-app check-all check-all--2 check-all--3 lint-app"))
+		Expect(content).To(ContainSubstring(
+			"## Build the app\n" +
+				"build-app: export VERSION ?= 1.0\n" +
+				"build-app:\n" +
+				"\t@cd src\n" +
+				"\t: \"$${TOKEN:?set TOKEN to the value of secret api-token}\"\n" +
+				"\texport MODE=release\n" +
+				"\tgo build -ldflags \"-X main.version=$$VERSION\" ./...\n",
+		))
+		By("requiring args without a default")
+		Expect(content).To(ContainSubstring("test-app: export PKG ?=\ntest-app:\n\t@: \"$${PKG:?pass PKG=<value>}\"\n"))
+		By("running serial refs as sub-makes")
+		Expect(content).To(ContainSubstring(
+			"\t$(MAKE) --no-print-directory -C \"$(CURDIR)\" build-app VERSION=2.0 || true\n",
+		))
+		Expect(content).To(ContainSubstring("\t# flow export: step with condition"))
+		By("running parallel steps as prerequisites")
+		Expect(content).To(ContainSubstring("check-all: lint-app check-all--2 check-all--3\n"))
+		Expect(content).To(ContainSubstring(
+			"check-all--2:\n\t@cd app\n\t$(MAKE) --no-print-directory -C \"$(CURDIR)\" test-app PKG=./...\n",
+		))
+	})
+
+	It("should export a justfile", func() {
+		content := export(exporter.FormatJustfile).Content
+		Expect(content).To(ContainSubstring(
+			"# Build the app\n" +
+				"build-app $VERSION=\"1.0\":\n" +
+				"    #!/usr/bin/env sh\n" +
+				"    set -e\n" +
+				"    cd src\n",
+		))
+		Expect(content).To(ContainSubstring(`    echo "{{{{ not a template }}"`))
+		Expect(content).To(ContainSubstring("test-app $PKG:\n"))
+		Expect(content).To(ContainSubstring(
+			"    {{just_executable()}} --justfile {{justfile()}} test-app ./pkg/... || true\n",
+		))
+		Expect(content).To(ContainSubstring(`check-all: lint-app (test-app "./...") _check-all-3`))
+		Expect(content).To(ContainSubstring("# flow export: just runs the dependencies of a recipe one at a time"))
+	})
+
+	It("should export a GitHub Actions workflow", func() {
+		result := export(exporter.FormatGitHubActions)
+		Expect(issues(result)).To(ContainElement(
+			"test ws/app:app: arg PKG is required; it's read from the repository variable PKG",
+		))
+		content := result.Content
+		Expect(content).To(ContainSubstring("      PKG: \"${{ vars.PKG }}\"\n"))
+		By("inlining the referenced executables of serial steps")
+		Expect(content).To(ContainSubstring(
+			"  release-app:\n" +
+				"    # flow export: step with condition `env[\"CI\"] == \"true\"` can't be expressed and is skipped\n" +
+				"    # flow export: referenced executable open app:docs isn't exported; the step is skipped\n" +
+				"    name: \"Build and test the app\"\n" +
+				"    runs-on: ubuntu-latest\n" +
+				"    env:\n" +
+				"      TOKEN: \"${{ secrets.TOKEN }}\"\n",
+		))
+		Expect(content).To(ContainSubstring(
+			"      - name: \"test-app\"\n" +
+				"        working-directory: \"app\"\n" +
+				"        continue-on-error: true\n" +
+				"        run: |\n" +
+				"          export PKG=./pkg/...\n" +
+				"          go test $PKG\n",
+		))
+		By("running parallel steps as jobs")
+		Expect(content).To(ContainSubstring("    needs: [check-all-1, check-all-2, check-all-3]\n"))
+	})
+
+	It("should export a GitLab CI pipeline", func() {
+		result := export(exporter.FormatGitLabCI)
+		Expect(issues(result)).To(ContainElement(
+			"test ws/app:app: arg PKG is required; it must be set as a CI/CD variable",
+		))
+		content := result.Content
+		Expect(content).To(ContainSubstring(
+			"build-app:\n" +
+				"  variables:\n" +
+				"    VERSION: \"1.0\"\n" +
+				"  script:\n" +
+				"    - |\n" +
+				"      (\n" +
+				"        cd \"$CI_PROJECT_DIR\"/src\n",
+		))
+		Expect(content).To(ContainSubstring("check-all:\n  needs: [check-all-1, check-all-2, check-all-3]\n"))
+		Expect(content).To(ContainSubstring("        echo done\n      ) || true\n"))
+	})
+
+	It("should fail for unsupported formats", func() {
+		_, err := exporter.Export("ant", execs)
+		Expect(err).To(MatchError(ContainSubstring(`unsupported export format "ant"`)))
+	})
+})
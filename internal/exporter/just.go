@@ -0,0 +1,179 @@
+package exporter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/flowexec/flow/v2/internal/utils"
+	"github.com/flowexec/flow/v2/types/executable"
+)
+
+// justSubCmd runs another recipe of the exported justfile from within a recipe that may have changed directory.
+const justSubCmd = "{{just_executable()}} --justfile {{justfile()}}"
+
+// justfile renders the tasks as just recipes. Args are exported recipe parameters, and parallel executables depend on
+// their steps. Recipes are shebang scripts so that their directory and params apply to all of their lines.
+func (x *exporter) justfile() string {
+	var b strings.Builder
+	b.WriteString("# Generated by `flow export justfile`.\n")
+	fmt.Fprintf(&b, "# Constructs that can't be expressed in just are flagged with %q comments.\n", issueMarker)
+	for _, t := range x.tasks {
+		if t.kind == kindParallel {
+			x.flag(t.e, "just runs the dependencies of a recipe one at a time; the steps don't run in parallel")
+		}
+	}
+	for _, t := range x.tasks {
+		x.justRecipe(&b, t)
+	}
+	return b.String()
+}
+
+func (x *exporter) justRecipe(b *strings.Builder, t *task) {
+	b.WriteString("\n")
+	for _, msg := range x.issuesFor(t.e) {
+		fmt.Fprintf(b, "# %s %s\n", issueMarker, msg)
+	}
+	// The comment right above a recipe is its documentation in `just --list`.
+	if desc := description(t.e); desc != "" {
+		fmt.Fprintf(b, "# %s\n", desc)
+	}
+
+	header := t.name
+	for _, a := range justParams(t.args) {
+		if a.Required && a.Default == "" {
+			header += " $" + a.EnvKey
+		} else {
+			header += fmt.Sprintf(" $%s=%s", a.EnvKey, strconv.Quote(a.Default))
+		}
+	}
+
+	if t.kind == kindParallel {
+		var deps []string
+		var recipe []string
+		var helpers strings.Builder
+		for i, s := range t.steps {
+			switch {
+			case s.skipped != "":
+				recipe = append(recipe, fmt.Sprintf("# %s %s", issueMarker, s.skipped))
+			case s.ref != nil && !s.ignoreFailure:
+				deps = append(deps, justDependency(s))
+			default:
+				helper := fmt.Sprintf("_%s-%d", t.name, i+1)
+				deps = append(deps, helper)
+				fmt.Fprintf(&helpers, "\n%s:\n", helper)
+				writeJustRecipe(&helpers, append(justEscapeAll(setupLines(t)), justStep(s)...))
+			}
+		}
+		fmt.Fprintf(b, "%s:", header)
+		for _, d := range deps {
+			b.WriteString(" " + d)
+		}
+		b.WriteString("\n")
+		writeJustRecipe(b, recipe)
+		b.WriteString(helpers.String())
+		return
+	}
+
+	recipe := justEscapeAll(setupLines(t))
+	for _, s := range t.steps {
+		recipe = append(recipe, justStep(s)...)
+	}
+	fmt.Fprintf(b, "%s:\n", header)
+	writeJustRecipe(b, recipe)
+}
+
+// justParams returns the args that are recipe parameters. just requires the parameters without a default value to come
+// first, and they're positional, so positional args keep their order.
+func justParams(args executable.ArgumentList) []executable.Argument {
+	var required, optional []executable.Argument
+	for _, a := range args {
+		switch {
+		case a.EnvKey == "":
+		case a.Required && a.Default == "":
+			required = append(required, a)
+		default:
+			optional = append(optional, a)
+		}
+	}
+	return append(required, optional...)
+}
+
+// justRefValues returns the positional values for the parameters of a referenced recipe. Parameters before the last
+// one that's given a value get their default.
+func justRefValues(s step) []string {
+	params := justParams(argsOf(s.ref.e))
+	values := make([]string, len(params))
+	last := -1
+	for i, p := range params {
+		values[i] = p.Default
+		for _, kv := range s.refArgs {
+			if kv[0] == p.EnvKey {
+				values[i] = kv[1]
+				last = i
+			}
+		}
+		if p.Required && p.Default == "" {
+			last = max(last, i)
+		}
+	}
+	return values[:last+1]
+}
+
+func justDependency(s step) string {
+	values := justRefValues(s)
+	if len(values) == 0 {
+		return s.ref.name
+	}
+	quoted := make([]string, 0, len(values))
+	for _, v := range values {
+		quoted = append(quoted, strconv.Quote(v))
+	}
+	return fmt.Sprintf("(%s %s)", s.ref.name, strings.Join(quoted, " "))
+}
+
+// justStep returns the recipe lines of a step, with `{{` already escaped for just.
+func justStep(s step) []string {
+	switch {
+	case s.skipped != "":
+		return []string{fmt.Sprintf("# %s %s", issueMarker, s.skipped)}
+	case s.ref != nil:
+		line := justSubCmd + " " + s.ref.name
+		for _, v := range justRefValues(s) {
+			line += " " + justEscape(utils.ShellQuote(v))
+		}
+		if s.ignoreFailure {
+			line += " || true"
+		}
+		return []string{line}
+	default:
+		lines := justEscapeAll(cmdLines(s.cmd))
+		if s.ignoreFailure && len(lines) > 0 {
+			lines = append(append([]string{"("}, lines...), ") || true")
+		}
+		return lines
+	}
+}
+
+// writeJustRecipe writes the indented lines of a recipe as a shebang script that exits on the first failure.
+func writeJustRecipe(b *strings.Builder, lines []string) {
+	if len(lines) == 0 {
+		return
+	}
+	b.WriteString("    #!/usr/bin/env sh\n    set -e\n")
+	for _, l := range lines {
+		fmt.Fprintf(b, "    %s\n", l)
+	}
+}
+
+func justEscape(s string) string {
+	return strings.ReplaceAll(s, "{{", "{{{{")
+}
+
+func justEscapeAll(lines []string) []string {
+	escaped := make([]string, 0, len(lines))
+	for _, l := range lines {
+		escaped = append(escaped, justEscape(l))
+	}
+	return escaped
+}
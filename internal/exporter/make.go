@@ -0,0 +1,143 @@
+package exporter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/flowexec/flow/v2/internal/utils"
+)
+
+// makeSubCmd runs another target of the exported Makefile from within a recipe that may have changed directory.
+const makeSubCmd = "$(MAKE) --no-print-directory -C \"$(CURDIR)\""
+
+// makefile renders the tasks as GNU Makefile targets. Each recipe runs in a single shell so that its directory and
+// params apply to all of its lines. Args are target-specific variables that can be overridden with `make target
+// NAME=value`, and parallel executables depend on their steps as prerequisites so that `make -j` runs them in parallel.
+func (x *exporter) makefile() string {
+	var b strings.Builder
+	b.WriteString("# Generated by `flow export makefile`.\n")
+	fmt.Fprintf(&b, "# Constructs that can't be expressed in make are flagged with %q comments.\n", issueMarker)
+	b.WriteString("# Run parallel targets with `make -j` to run their prerequisites in parallel.\n\n")
+	b.WriteString(".ONESHELL:\nSHELL := /bin/sh\n.SHELLFLAGS := -ec\n\n")
+
+	var phony []string
+	var targets strings.Builder
+	for _, t := range x.tasks {
+		phony = append(phony, x.makeTarget(&targets, t)...)
+	}
+	if len(phony) > 0 {
+		fmt.Fprintf(&b, ".PHONY: %s\n", strings.Join(phony, " "))
+	}
+	b.WriteString(targets.String())
+	return b.String()
+}
+
+// makeTarget writes the target of a task, along with the helper targets of its parallel steps, and returns their names.
+func (x *exporter) makeTarget(b *strings.Builder, t *task) []string {
+	b.WriteString("\n")
+	for _, msg := range x.issuesFor(t.e) {
+		fmt.Fprintf(b, "# %s %s\n", issueMarker, msg)
+	}
+	if desc := description(t.e); desc != "" {
+		fmt.Fprintf(b, "## %s\n", desc)
+	}
+	for _, a := range t.args {
+		if a.EnvKey != "" {
+			line := fmt.Sprintf("%s: export %s ?=", t.name, a.EnvKey)
+			if a.Default != "" {
+				line += " " + makeEscape(a.Default)
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+
+	var recipe []string
+	for _, a := range t.args {
+		if a.EnvKey != "" && a.Required && a.Default == "" {
+			recipe = append(recipe, makeEscape(requireEnv(a.EnvKey, "pass "+a.EnvKey+"=<value>")))
+		}
+	}
+
+	names := []string{t.name}
+	if t.kind == kindParallel {
+		var prereqs []string
+		var helpers strings.Builder
+		for i, s := range t.steps {
+			switch {
+			case s.skipped != "":
+				recipe = append(recipe, fmt.Sprintf("# %s %s", issueMarker, s.skipped))
+			case s.ref != nil && len(s.refArgs) == 0 && !s.ignoreFailure:
+				prereqs = append(prereqs, s.ref.name)
+			default:
+				helper := fmt.Sprintf("%s--%d", t.name, i+1)
+				prereqs = append(prereqs, helper)
+				names = append(names, helper)
+				lines := append(makeEscapeAll(setupLines(t)), makeStep(s)...)
+				fmt.Fprintf(&helpers, "\n%s:\n", helper)
+				writeMakeRecipe(&helpers, lines)
+			}
+		}
+		fmt.Fprintf(b, "%s:", t.name)
+		for _, p := range prereqs {
+			b.WriteString(" " + p)
+		}
+		b.WriteString("\n")
+		writeMakeRecipe(b, recipe)
+		b.WriteString(helpers.String())
+		return names
+	}
+
+	recipe = append(recipe, makeEscapeAll(setupLines(t))...)
+	for _, s := range t.steps {
+		recipe = append(recipe, makeStep(s)...)
+	}
+	fmt.Fprintf(b, "%s:\n", t.name)
+	writeMakeRecipe(b, recipe)
+	return names
+}
+
+// makeStep returns the recipe lines of a step, with the shell's `$` already escaped for make.
+func makeStep(s step) []string {
+	switch {
+	case s.skipped != "":
+		return []string{fmt.Sprintf("# %s %s", issueMarker, s.skipped)}
+	case s.ref != nil:
+		line := makeSubCmd + " " + s.ref.name
+		for _, kv := range s.refArgs {
+			line += " " + makeEscape(utils.ShellQuote(kv[0]+"="+kv[1]))
+		}
+		if s.ignoreFailure {
+			line += " || true"
+		}
+		return []string{line}
+	default:
+		lines := makeEscapeAll(cmdLines(s.cmd))
+		if s.ignoreFailure && len(lines) > 0 {
+			lines = append(append([]string{"("}, lines...), ") || true")
+		}
+		return lines
+	}
+}
+
+// writeMakeRecipe writes the tab-indented lines of a recipe. Only the first line of a `.ONESHELL` recipe is checked for
+// the `@` prefix, which stops make from echoing the whole script.
+func writeMakeRecipe(b *strings.Builder, lines []string) {
+	for i, l := range lines {
+		if i == 0 {
+			l = "@" + l
+		}
+		fmt.Fprintf(b, "\t%s\n", l)
+	}
+}
+
+func makeEscape(s string) string {
+	return strings.ReplaceAll(s, "$", "$$")
+}
+
+func makeEscapeAll(lines []string) []string {
+	escaped := make([]string, 0, len(lines))
+	for _, l := range lines {
+		escaped = append(escaped, makeEscape(l))
+	}
+	return escaped
+}
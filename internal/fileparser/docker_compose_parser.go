@@ -3,7 +3,9 @@ package fileparser
 import (
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 
@@ -11,10 +13,53 @@ import (
 )
 
 type composeFile struct {
-	Services map[string]any `yaml:"services"`
+	Services map[string]composeService `yaml:"services"`
 }
 
-var composeTags = []string{generatedTag, "docker-compose"}
+type composeService struct {
+	Build       any            `yaml:"build"`
+	Image       string         `yaml:"image"`
+	Profiles    []string       `yaml:"profiles"`
+	Healthcheck *composeHealth `yaml:"healthcheck"`
+}
+
+type composeHealth struct {
+	Disable bool `yaml:"disable"`
+}
+
+// composeTool is the compose implementation that the generated executables run.
+type composeTool struct {
+	cmd string
+	// inspectCmd is the container runtime command used to check a service's health when the tool can't wait for it.
+	inspectCmd string
+	// supportsWait is true when `up --wait` waits for services to be healthy.
+	supportsWait bool
+}
+
+// composeHealthTimeout is how long, in seconds, the generated executables of tools that can't wait for services
+// poll their health before giving up.
+const composeHealthTimeout = 120
+
+var (
+	composeTags = []string{generatedTag, "docker-compose"}
+
+	// composeTools are looked up in order; the first one found on the PATH is used.
+	composeTools = []struct {
+		binary string
+		// probe is the arguments of a command that succeeds only if the binary provides the tool (e.g. when the
+		// compose plugin is installed for docker).
+		probe []string
+		tool  composeTool
+	}{
+		{
+			binary: "docker",
+			probe:  []string{"compose", "version"},
+			tool:   composeTool{cmd: "docker compose", inspectCmd: "docker", supportsWait: true},
+		},
+		{binary: "docker-compose", tool: composeTool{cmd: "docker-compose", inspectCmd: "docker"}},
+		{binary: "podman", tool: composeTool{cmd: "podman compose", inspectCmd: "podman"}},
+	}
+)
 
 // ExecutablesFromDockerCompose parses a docker-compose.yml and returns list of Executables for the services
 func ExecutablesFromDockerCompose(wsPath, path string) (executable.ExecutableList, error) {
@@ -30,57 +75,137 @@ func ExecutablesFromDockerCompose(wsPath, path string) (executable.ExecutableLis
 		return nil, fmt.Errorf("failed to decode docker compose file: %w", err)
 	}
 
+	tool := detectComposeTool()
 	execs := make(executable.ExecutableList, 0)
 	dir := executable.Directory(shortenWsPath(wsPath, filepath.Dir(path)))
-	// Per-service start/build
-	for svc, data := range cf.Services {
-		execs = append(execs, &executable.Executable{
-			Name:        svc,
-			Verb:        executable.VerbStart,
+	newExec := func(verb executable.Verb, name, description, cmd string, aliases ...string) *executable.Executable {
+		e := &executable.Executable{
+			Name:        name,
+			Verb:        verb,
+			Aliases:     aliases,
 			Tags:        composeTags,
-			Description: fmt.Sprintf("Start service %s via docker-compose", svc),
+			Description: description,
 			Exec: &executable.ExecExecutableType{
 				Dir: dir,
-				Cmd: fmt.Sprintf("docker-compose up %s", svc),
+				Cmd: cmd,
 			},
-		})
-
-		dataMap, ok := data.(map[string]any)
-		if ok && dataMap["build"] != nil {
-			execs = append(execs, &executable.Executable{
-				Name:        svc,
-				Verb:        executable.VerbBuild,
-				Tags:        composeTags,
-				Description: fmt.Sprintf("Build service %s via docker-compose", svc),
-				Exec: &executable.ExecExecutableType{
-					Dir: dir,
-					Cmd: fmt.Sprintf("docker-compose build %s", svc),
-				},
-			})
+		}
+		execs = append(execs, e)
+		return e
+	}
+
+	services := sortedKeys(cf.Services)
+	profiles := make(map[string]bool)
+	// healthchecked are the services with a healthcheck that are started by default.
+	var healthchecked []string
+	for _, svc := range services {
+		data := cf.Services[svc]
+		name := NormalizeName(svc, "")
+		compose := tool.cmd
+		// Services in profiles aren't part of the project unless one of their profiles is enabled.
+		for _, p := range data.Profiles {
+			profiles[p] = true
+		}
+		if len(data.Profiles) > 0 {
+			compose = fmt.Sprintf("%s --profile %s", tool.cmd, data.Profiles[0])
+		}
+
+		newExec(executable.VerbStart, name,
+			fmt.Sprintf("Start service %s via %s", svc, tool.cmd), fmt.Sprintf("%s up %s", compose, svc))
+		newExec(executable.VerbStop, name,
+			fmt.Sprintf("Stop service %s via %s", svc, tool.cmd), fmt.Sprintf("%s stop %s", compose, svc))
+		newExec(executable.VerbRestart, name,
+			fmt.Sprintf("Restart service %s via %s", svc, tool.cmd), fmt.Sprintf("%s restart %s", compose, svc))
+		newExec(executable.VerbWatch, name,
+			fmt.Sprintf("Follow the logs of service %s via %s", svc, tool.cmd),
+			fmt.Sprintf("%s logs --follow %s", compose, svc), name+"-logs")
+		newExec(executable.VerbConnect, name,
+			fmt.Sprintf("Open a shell in the running container of service %s via %s", svc, tool.cmd),
+			fmt.Sprintf("%s exec %s sh", compose, svc), name+"-shell")
+		if data.Build != nil {
+			newExec(executable.VerbBuild, name,
+				fmt.Sprintf("Build service %s via %s", svc, tool.cmd), fmt.Sprintf("%s build %s", compose, svc))
+		}
+		if data.Image != "" {
+			newExec(executable.VerbFetch, name,
+				fmt.Sprintf("Pull the image of service %s via %s", svc, tool.cmd),
+				fmt.Sprintf("%s pull %s", compose, svc), name+"-pull")
+		}
+		if data.Healthcheck != nil && !data.Healthcheck.Disable {
+			if len(data.Profiles) == 0 {
+				healthchecked = append(healthchecked, svc)
+			}
+			newExec(executable.VerbStart, name+"-healthy",
+				fmt.Sprintf("Start service %s in the background and wait until it's healthy", svc),
+				tool.waitCmd(compose, svc, []string{svc}))
 		}
 	}
 
 	// start and stop all
-	execs = append(execs, &executable.Executable{
-		Verb:        executable.VerbStart,
-		Aliases:     []string{"all", "services"},
-		Tags:        composeTags,
-		Description: "Start all services via docker-compose",
-		Exec: &executable.ExecExecutableType{
-			Dir: dir,
-			Cmd: "docker-compose up",
-		},
-	})
-	execs = append(execs, &executable.Executable{
-		Verb:        executable.VerbStop,
-		Aliases:     []string{"all", "services"},
-		Tags:        composeTags,
-		Description: "Stop all services via docker-compose",
-		Exec: &executable.ExecExecutableType{
-			Dir: dir,
-			Cmd: "docker-compose down",
-		},
-	})
+	newExec(executable.VerbStart, "", fmt.Sprintf("Start all services via %s", tool.cmd),
+		fmt.Sprintf("%s up", tool.cmd), "all", "services")
+	newExec(executable.VerbStop, "", fmt.Sprintf("Stop all services via %s", tool.cmd),
+		fmt.Sprintf("%s stop", tool.cmd), "all", "services")
+	newExec(executable.VerbRestart, "", fmt.Sprintf("Restart all services via %s", tool.cmd),
+		fmt.Sprintf("%s restart", tool.cmd), "all", "services")
+	newExec(executable.VerbTeardown, "", fmt.Sprintf("Stop and remove all containers and networks via %s", tool.cmd),
+		fmt.Sprintf("%s down", tool.cmd), "down")
+	newExec(executable.VerbFetch, "", fmt.Sprintf("Pull the images of all services via %s", tool.cmd),
+		fmt.Sprintf("%s pull", tool.cmd), "pull")
+	newExec(executable.VerbWatch, "", fmt.Sprintf("Follow the logs of all services via %s", tool.cmd),
+		fmt.Sprintf("%s logs --follow", tool.cmd), "logs")
+	if len(healthchecked) > 0 {
+		newExec(executable.VerbStart, "healthy",
+			"Start all services in the background and wait until they're healthy",
+			tool.waitCmd(tool.cmd, "", healthchecked))
+	}
+
+	for _, p := range sortedKeys(profiles) {
+		newExec(executable.VerbStart, "profile-"+NormalizeName(p, ""),
+			fmt.Sprintf("Start all services, including those in profile %s, via %s", p, tool.cmd),
+			fmt.Sprintf("%s --profile %s up", tool.cmd, p))
+	}
 
 	return execs, nil
 }
+
+// detectComposeTool returns the first compose implementation found on the PATH, defaulting to `docker compose`.
+func detectComposeTool() composeTool {
+	for _, t := range composeTools {
+		path, err := exec.LookPath(t.binary)
+		if err != nil {
+			continue
+		}
+		if len(t.probe) > 0 && exec.Command(path, t.probe...).Run() != nil { //nolint:gosec
+			continue
+		}
+		return t.tool
+	}
+	return composeTools[0].tool
+}
+
+// waitCmd returns the command for starting a service (or all services) in the background and waiting until the
+// containers of the healthchecked services are healthy. Tools that don't support `up --wait` poll the containers'
+// health status instead, failing after composeHealthTimeout.
+func (t composeTool) waitCmd(compose, svc string, healthchecked []string) string {
+	if t.supportsWait {
+		return strings.TrimSpace(fmt.Sprintf("%s up --detach --wait %s", compose, svc))
+	}
+	return fmt.Sprintf(
+		"%s && for c in $(%s ps -q %s); do n=0; "+
+			"until [ \"$(%s inspect -f '{{.State.Health.Status}}' \"$c\")\" = healthy ]; do "+
+			"n=$((n+2)); if [ $n -gt %d ]; then echo \"timed out waiting for $c to be healthy\" >&2; exit 1; fi; "+
+			"sleep 2; done; done",
+		strings.TrimSpace(fmt.Sprintf("%s up --detach %s", compose, svc)),
+		compose, strings.Join(healthchecked, " "), t.inspectCmd, composeHealthTimeout,
+	)
+}
+
+// isComposeFile reports whether fn is one of the file names that compose looks up by default.
+func isComposeFile(fn string) bool {
+	switch strings.ToLower(fn) {
+	case "docker-compose.yml", "docker-compose.yaml", "compose.yml", "compose.yaml":
+		return true
+	}
+	return false
+}
@@ -2,21 +2,34 @@ package fileparser_test
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	"github.com/flowexec/flow/v2/internal/fileparser"
+	"github.com/flowexec/flow/v2/types/executable"
 )
 
 var _ = Describe("ExecutablesFromDockerCompose", func() {
 	const composePath = "testdata/docker-compose.yml"
 
+	// usePath replaces the PATH with a directory containing only the given binaries.
+	usePath := func(binaries ...string) {
+		dir := GinkgoT().TempDir()
+		for _, b := range binaries {
+			Expect(os.WriteFile(filepath.Join(dir, b), []byte("#!/bin/sh\n"), 0o755)).To(Succeed()) //nolint:gosec
+		}
+		GinkgoT().Setenv("PATH", dir)
+	}
+
 	It("should parse docker-compose.yml", func() {
+		usePath("docker-compose")
 		execs, err := fileparser.ExecutablesFromDockerCompose("", composePath)
 		Expect(err).NotTo(HaveOccurred())
-		Expect(execs).To(HaveLen(6))
+		Expect(execs).To(HaveLen(24))
 
 		found := map[string]bool{
 			"start":       false,
@@ -40,4 +53,64 @@ var _ = Describe("ExecutablesFromDockerCompose", func() {
 			Expect(found).To(BeTrue(), "executable %s not found", ref)
 		}
 	})
+
+	It("should generate lifecycle executables for each service", func() {
+		usePath("docker")
+		execs, err := fileparser.ExecutablesFromDockerCompose("", "testdata/compose.yaml")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(findExecutable(execs, executable.VerbStop, "", "db").Exec.Cmd).To(Equal("docker compose stop db"))
+		Expect(findExecutable(execs, executable.VerbRestart, "", "db").Exec.Cmd).To(Equal("docker compose restart db"))
+		Expect(findExecutable(execs, executable.VerbWatch, "", "db").Exec.Cmd).To(Equal("docker compose logs --follow db"))
+		Expect(findExecutable(execs, executable.VerbConnect, "", "db").Exec.Cmd).To(Equal("docker compose exec db sh"))
+		Expect(findExecutable(execs, executable.VerbFetch, "", "db").Exec.Cmd).To(Equal("docker compose pull db"))
+		Expect(findExecutable(execs, executable.VerbTeardown, "", "").Exec.Cmd).To(Equal("docker compose down"))
+		Expect(findExecutable(execs, executable.VerbFetch, "", "").Exec.Cmd).To(Equal("docker compose pull"))
+	})
+
+	It("should generate wait until healthy variants for services with healthchecks", func() {
+		usePath("docker")
+		execs, err := fileparser.ExecutablesFromDockerCompose("", "testdata/compose.yaml")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(findExecutable(execs, executable.VerbStart, "", "api-healthy").Exec.Cmd).
+			To(Equal("docker compose up --detach --wait api"))
+		Expect(findExecutable(execs, executable.VerbStart, "", "healthy").Exec.Cmd).
+			To(Equal("docker compose up --detach --wait"))
+		for _, e := range execs {
+			Expect(e.Name).NotTo(Equal("debugger-healthy"))
+		}
+	})
+
+	It("should enable the profiles of services in profiles", func() {
+		usePath("podman")
+		execs, err := fileparser.ExecutablesFromDockerCompose("", "testdata/compose.yaml")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(findExecutable(execs, executable.VerbStart, "", "debugger").Exec.Cmd).
+			To(Equal("podman compose --profile debug up debugger"))
+		Expect(findExecutable(execs, executable.VerbStart, "", "profile-debug").Exec.Cmd).
+			To(Equal("podman compose --profile debug up"))
+		Expect(findExecutable(execs, executable.VerbStart, "", "db-healthy").Exec.Cmd).
+			To(HavePrefix("podman compose up --detach db && for c in $(podman compose ps -q db)"))
+	})
+
+	It("should only poll the health of services with healthchecks", func() {
+		usePath("podman")
+		execs, err := fileparser.ExecutablesFromDockerCompose("", "testdata/compose.yaml")
+		Expect(err).NotTo(HaveOccurred())
+
+		cmd := findExecutable(execs, executable.VerbStart, "", "healthy").Exec.Cmd
+		Expect(cmd).To(HavePrefix("podman compose up --detach && for c in $(podman compose ps -q api db)"))
+		Expect(cmd).To(ContainSubstring("if [ $n -gt 120 ]; then"))
+	})
+
+	It("should use docker-compose when docker doesn't have the compose plugin", func() {
+		usePath("docker-compose")
+		dir := os.Getenv("PATH")
+		Expect(os.WriteFile(filepath.Join(dir, "docker"), []byte("#!/bin/sh\nexit 1\n"), 0o755)).To(Succeed()) //nolint:gosec
+		execs, err := fileparser.ExecutablesFromDockerCompose("", "testdata/compose.yaml")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(findExecutable(execs, executable.VerbStop, "", "db").Exec.Cmd).To(Equal("docker-compose stop db"))
+	})
 })
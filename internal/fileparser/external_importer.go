@@ -0,0 +1,238 @@
+package fileparser
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/flowexec/flow/v2/internal/validation"
+	"github.com/flowexec/flow/v2/pkg/filesystem"
+	"github.com/flowexec/flow/v2/pkg/logger"
+	"github.com/flowexec/flow/v2/types/common"
+	"github.com/flowexec/flow/v2/types/config"
+	"github.com/flowexec/flow/v2/types/executable"
+)
+
+const (
+	externalImporterPrefix  = "flow-import-"
+	externalImporterTimeout = 30 * time.Second
+)
+
+// externalImporter is a program that generates executables from the imported files matching its patterns.
+type externalImporter struct {
+	name     string
+	cmd      string
+	args     []string
+	patterns []string
+}
+
+// externalImporters are the importers declared in the user's config and found on the PATH.
+type externalImporters struct {
+	// searchPath and configPath are the PATH and config file that the importers were loaded from.
+	searchPath, configPath string
+	declared, discovered   []*externalImporter
+}
+
+var (
+	// loadedImporters is loaded once per process, since imports are generated every time a flow file is loaded. It's
+	// only reloaded if the PATH or config file changes.
+	loadedImporters   *externalImporters
+	loadedImportersMu sync.Mutex
+)
+
+func loadExternalImporters() *externalImporters {
+	loadedImportersMu.Lock()
+	defer loadedImportersMu.Unlock()
+	searchPath, configPath := os.Getenv("PATH"), filesystem.UserConfigFilePath()
+	if loadedImporters == nil ||
+		loadedImporters.searchPath != searchPath || loadedImporters.configPath != configPath {
+		loadedImporters = &externalImporters{
+			searchPath: searchPath,
+			configPath: configPath,
+			declared:   declaredImporters(),
+			discovered: discoveredImporters(),
+		}
+	}
+	return loadedImporters
+}
+
+// findExternalImporter returns the importer for the file at path. Importers declared in the user's config are matched
+// when declared is true, otherwise the `flow-import-*` executables on the PATH are.
+func findExternalImporter(path string, declared bool) *externalImporter {
+	loaded := loadExternalImporters()
+	importers := loaded.discovered
+	if declared {
+		importers = loaded.declared
+	}
+	fn := filepath.Base(path)
+	for _, imp := range importers {
+		for _, pattern := range imp.patterns {
+			if matched, err := filepath.Match(pattern, fn); err == nil && matched {
+				return imp
+			}
+		}
+	}
+	return nil
+}
+
+func declaredImporters() []*externalImporter {
+	// The user's config is only read if it exists; importing shouldn't create it.
+	if _, err := os.Stat(filesystem.UserConfigFilePath()); err != nil {
+		return nil
+	}
+	cfg, err := filesystem.LoadConfig()
+	if err != nil {
+		logger.Log().Debug("unable to load external importers from config", "err", err)
+		return nil
+	}
+	importers := make([]*externalImporter, 0, len(cfg.Importers))
+	for _, imp := range cfg.Importers {
+		importers = append(importers, newDeclaredImporter(imp))
+	}
+	return importers
+}
+
+func newDeclaredImporter(imp config.Importer) *externalImporter {
+	name := imp.Name
+	if name == "" {
+		name = strings.TrimPrefix(importerBaseName(imp.Command), externalImporterPrefix)
+	}
+	return &externalImporter{name: name, cmd: imp.Command, args: imp.Args, patterns: imp.Patterns}
+}
+
+// discoveredImporters returns the `flow-import-<name>` executables found on the PATH. Each one is run with
+// `--patterns` to list the filename patterns that it handles, one per line.
+func discoveredImporters() []*externalImporter {
+	var importers []*externalImporter
+	seen := make(map[string]bool)
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name := importerBaseName(entry.Name())
+			if !strings.HasPrefix(name, externalImporterPrefix) || entry.IsDir() || seen[name] {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			if !isExecutableFile(path) {
+				continue
+			}
+			// Executables earlier in the PATH shadow the ones after them.
+			seen[name] = true
+			patterns, err := discoveredImporterPatterns(path)
+			if err != nil {
+				logger.Log().Warn("unable to list the patterns of external importer", "importer", path, "err", err)
+				continue
+			}
+			importers = append(importers, &externalImporter{
+				name:     strings.TrimPrefix(name, externalImporterPrefix),
+				cmd:      path,
+				patterns: patterns,
+			})
+		}
+	}
+	return importers
+}
+
+func discoveredImporterPatterns(path string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), externalImporterTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, path, "--patterns").Output()
+	if err != nil {
+		return nil, err
+	}
+	var patterns []string
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		if p := strings.TrimSpace(scanner.Text()); p != "" {
+			patterns = append(patterns, p)
+		}
+	}
+	return patterns, nil
+}
+
+// executables runs the importer for the file at path and returns the executables that it prints. The output is
+// validated against the flow file schema's executables before it's decoded.
+func (i *externalImporter) executables(wsPath, path string) (executable.ExecutableList, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), externalImporterTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, i.cmd, append(append([]string{}, i.args...), path)...) //nolint:gosec
+	cmd.Dir = filepath.Dir(path)
+	cmd.Env = append(os.Environ(), "FLOW_WORKSPACE_PATH="+wsPath)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("external importer %s failed: %w: %s", i.name, err, msg)
+		}
+		return nil, fmt.Errorf("external importer %s failed: %w", i.name, err)
+	}
+
+	var raw []json.RawMessage
+	if err := json.Unmarshal(out, &raw); err != nil {
+		return nil, fmt.Errorf("external importer %s printed invalid JSON: %w", i.name, err)
+	}
+	doc, err := json.Marshal(map[string]any{"executables": raw})
+	if err != nil {
+		return nil, err
+	}
+	result, err := validation.ValidateBytes(doc, validation.FileTypeFlowFile, false)
+	if err != nil {
+		return nil, fmt.Errorf("unable to validate the output of external importer %s: %w", i.name, err)
+	}
+	if !result.Valid {
+		issues := make([]string, 0, len(result.Errors))
+		for _, issue := range result.Errors {
+			issues = append(issues, issue.String())
+		}
+		return nil, fmt.Errorf(
+			"external importer %s printed invalid executables: %s", i.name, strings.Join(issues, "; "),
+		)
+	}
+
+	var execs executable.ExecutableList
+	if err := json.Unmarshal(out, &execs); err != nil {
+		return nil, fmt.Errorf("unable to decode the output of external importer %s: %w", i.name, err)
+	}
+	for _, e := range execs {
+		for _, tag := range []string{generatedTag, i.name} {
+			if tag != "" && !common.Tags(e.Tags).HasTag(tag) {
+				e.Tags = append(e.Tags, tag)
+			}
+		}
+	}
+	return execs, nil
+}
+
+// importerBaseName returns the name of an importer's executable without the extension that Windows requires.
+func importerBaseName(path string) string {
+	name := filepath.Base(path)
+	if runtime.GOOS == "windows" {
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+	return name
+}
+
+func isExecutableFile(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		return strings.EqualFold(filepath.Ext(path), ".exe")
+	}
+	return info.Mode()&0o111 != 0
+}
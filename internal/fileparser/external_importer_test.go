@@ -0,0 +1,121 @@
+package fileparser_test
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/flowexec/tuikit/io/mocks"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/flowexec/flow/v2/internal/fileparser"
+	"github.com/flowexec/flow/v2/pkg/logger"
+	"github.com/flowexec/flow/v2/types/executable"
+)
+
+var _ = Describe("External importers", func() {
+	var (
+		mockLogger *mocks.MockLogger
+		tmpDir     string
+		flowFile   *executable.FlowFile
+	)
+
+	writeFile := func(path, content string, mode os.FileMode) {
+		Expect(os.MkdirAll(filepath.Dir(path), 0750)).To(Succeed())
+		Expect(os.WriteFile(path, []byte(content), mode)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		mockLogger = mocks.NewMockLogger(ctrl)
+		logger.Init(logger.InitOptions{Logger: mockLogger, TestingTB: GinkgoTB()})
+		mockLogger.EXPECT().Debugf(gomock.Any(), gomock.Any()).AnyTimes()
+
+		tmpDir = GinkgoT().TempDir()
+		GinkgoT().Setenv("PATH", filepath.Join(tmpDir, "bin"))
+		GinkgoT().Setenv("FLOW_CONFIG_DIR", filepath.Join(tmpDir, "config"))
+
+		writeFile(filepath.Join(tmpDir, "ws", "Cargo.toml"), "[package]\nname = \"app\"\n", 0600)
+		writeFile(filepath.Join(tmpDir, "ws", "Chart.yaml"), "name: app\n", 0600)
+		writeFile(filepath.Join(tmpDir, "bin", "flow-import-cargo"), `#!/bin/sh
+if [ "$1" = "--patterns" ]; then
+  printf 'Cargo.toml\n'
+  exit 0
+fi
+printf '[{"verb": "build", "name": "app", "tags": ["rust"], "exec": {"cmd": "cargo build"}}]'
+`, 0700)
+
+		flowFile = &executable.FlowFile{}
+		flowFile.SetContext("ws", filepath.Join(tmpDir, "ws"), filepath.Join(tmpDir, "ws", "test"+executable.FlowFileExt))
+	})
+
+	It("should import files with the flow-import executables on the PATH", func() {
+		flowFile.Imports = executable.Imports{"Cargo.toml"}
+		result, err := fileparser.ExecutablesFromImports("ws", flowFile)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Verb).To(Equal(executable.VerbBuild))
+		Expect(result[0].Name).To(Equal("app"))
+		Expect(result[0].Tags).To(ConsistOf("rust", "generated", "cargo"))
+		Expect(result[0].Exec.Cmd).To(Equal("cargo build"))
+	})
+
+	It("should only look up the importers once", func() {
+		calls := filepath.Join(tmpDir, "calls")
+		writeFile(filepath.Join(tmpDir, "bin", "flow-import-cargo"), `#!/bin/sh
+if [ "$1" = "--patterns" ]; then
+  printf 'patterns\n' >> `+calls+`
+  printf 'Cargo.toml\n'
+  exit 0
+fi
+printf '[]'
+`, 0700)
+
+		flowFile.Imports = executable.Imports{"Cargo.toml", "Cargo.toml"}
+		_, err := fileparser.ExecutablesFromImports("ws", flowFile)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = fileparser.ExecutablesFromImports("ws", flowFile)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.ReadFile(calls)).To(Equal([]byte("patterns\n")))
+	})
+
+	It("should import files with the importers declared in the config", func() {
+		writeFile(filepath.Join(tmpDir, "bin", "helm-flow"), `#!/bin/sh
+printf '[{"verb": "deploy", "name": "%s", "exec": {"cmd": "helm upgrade --install %s ."}}]' "$1" "$2"
+`, 0700)
+		writeFile(filepath.Join(tmpDir, "config", "config.yaml"), `workspaces: {}
+currentWorkspace: ""
+importers:
+  - name: helm
+    command: helm-flow
+    args: [chart]
+    patterns: ["Chart.yaml"]
+`, 0600)
+
+		flowFile.Imports = executable.Imports{"Chart.yaml"}
+		result, err := fileparser.ExecutablesFromImports("ws", flowFile)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Verb).To(Equal(executable.VerbDeploy))
+		Expect(result[0].Name).To(Equal("chart"))
+		Expect(result[0].Tags).To(ConsistOf("generated", "helm"))
+		Expect(result[0].Exec.Cmd).To(Equal("helm upgrade --install " + filepath.Join(tmpDir, "ws", "Chart.yaml") + " ."))
+	})
+
+	It("should reject output that doesn't match the executable schema", func() {
+		writeFile(filepath.Join(tmpDir, "bin", "flow-import-cargo"), `#!/bin/sh
+if [ "$1" = "--patterns" ]; then
+  printf 'Cargo.toml\n'
+  exit 0
+fi
+printf '[{"verb": "build", "name": "app", "exec": {"cmd": 42}}]'
+`, 0700)
+		mockLogger.EXPECT().WrapError(gomock.Any(), gomock.Any()).Times(1)
+
+		flowFile.Imports = executable.Imports{"Cargo.toml"}
+		result, err := fileparser.ExecutablesFromImports("ws", flowFile)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(BeEmpty())
+	})
+})
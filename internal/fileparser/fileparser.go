@@ -6,8 +6,8 @@ import (
 	"path/filepath"
 	"strings"
 
-	"github.com/flowexec/flow/v2/internal/utils"
 	"github.com/flowexec/flow/v2/pkg/logger"
+	"github.com/flowexec/flow/v2/types/common"
 	"github.com/flowexec/flow/v2/types/executable"
 )
 
@@ -15,6 +15,32 @@ const generatedTag = "generated"
 
 func ExecutablesFromImports(
 	wsName string, flowFile *executable.FlowFile,
+) (executable.ExecutableList, error) {
+	state := &importState{including: map[string]bool{flowFile.ConfigPath(): true}}
+	return executablesFromImports(wsName, flowFile, state)
+}
+
+// SyncExecutablesFromImports is ExecutablesFromImports for syncing the cache: the refs of git imports are resolved
+// again, so that imports of a branch pick up its latest commit.
+func SyncExecutablesFromImports(
+	wsName string, flowFile *executable.FlowFile,
+) (executable.ExecutableList, error) {
+	state := &importState{including: map[string]bool{flowFile.ConfigPath(): true}, updateGitRefs: true}
+	return executablesFromImports(wsName, flowFile, state)
+}
+
+// importState is shared by the imports of a flow file and the flow files that it includes.
+type importState struct {
+	// including holds the flow files that are currently being included so that include cycles are reported instead
+	// of followed.
+	including map[string]bool
+	// updateGitRefs resolves the refs of git imports again instead of using the commits they last resolved to.
+	updateGitRefs bool
+}
+
+// executablesFromImports generates the executables for flowFile's imports.
+func executablesFromImports(
+	wsName string, flowFile *executable.FlowFile, state *importState,
 ) (executable.ExecutableList, error) {
 	executables := make(executable.ExecutableList, 0)
 	wsPath := flowFile.WorkspacePath()
@@ -24,67 +50,209 @@ func ExecutablesFromImports(
 
 	setCtx := func(execs ...*executable.Executable) {
 		for _, e := range execs {
-			e.SetContext(wsName, wsPath, flowFileNs, flowFilePath)
+			// Importers can scope executables to a namespace within the flow file's (e.g. included Taskfiles).
+			ns := joinNamespace(flowFileNs, e.Namespace())
+			e.SetContext(wsName, wsPath, ns, flowFilePath)
+			qualifyGeneratedRefs(e, flowFileNs)
 			e.SetInheritedFields(flowFile)
 		}
 	}
+	include := func(file, path string) {
+		included, err := executablesFromFlowFile(wsName, flowFile, path, state)
+		if err != nil {
+			logger.Log().WrapError(err, fmt.Sprintf("unable to include flow file %s", file))
+			return
+		}
+		executables = appendIncluded(flowFile, executables, included)
+	}
 
 	for _, file := range files {
-		fn := filepath.Base(file)
-		expandedFile := utils.ExpandPath(file, filepath.Dir(flowFilePath), nil)
-
-		if info, err := os.Stat(expandedFile); err != nil {
-			logger.Log().WrapError(err, fmt.Sprintf("unable to import executables from file %s", file))
-			continue
-		} else if info.IsDir() {
-			logger.Log().Error("unable to import executables", "err", fmt.Sprintf("%s is not a file", file))
+		if repo, path, ref, ok := parseGitImport(file); ok {
+			localPath, err := gitImportPath(repo, path, ref, state.updateGitRefs)
+			if err != nil {
+				logger.Log().WrapError(err, fmt.Sprintf("unable to import executables from %s", file))
+				continue
+			}
+			include(file, localPath)
 			continue
 		}
 
-		parsed, err := parseImportFile(wsPath, fn, expandedFile)
-		if err != nil {
-			logger.Log().WrapError(err, fmt.Sprintf("unable to import executables from file (%s)", file))
-			continue
+		for _, expandedFile := range expandImportPaths(file, flowFilePath) {
+			fn := filepath.Base(expandedFile)
+			if info, err := os.Stat(expandedFile); err != nil {
+				logger.Log().WrapError(err, fmt.Sprintf("unable to import executables from file %s", file))
+				continue
+			} else if info.IsDir() {
+				logger.Log().Error("unable to import executables", "err", fmt.Sprintf("%s is not a file", file))
+				continue
+			}
+
+			if executable.HasFlowFileExt(fn) {
+				include(file, expandedFile)
+				continue
+			}
+			importer := findImporter(fn, expandedFile)
+			if importer == nil {
+				if isFlowFileImport(expandedFile) {
+					include(file, expandedFile)
+				} else {
+					logger.Log().Warn("unable to import executables - unsupported file type", "file", fn)
+				}
+				continue
+			}
+
+			parsed, err := importer(wsPath, expandedFile)
+			if err != nil {
+				logger.Log().WrapError(err, fmt.Sprintf("unable to import executables from file (%s)", file))
+				continue
+			}
+			setCtx(parsed...)
+			executables = append(executables, parsed...)
 		}
-		setCtx(parsed...)
-		executables = append(executables, parsed...)
 	}
 
 	return executables, nil
 }
 
-func parseImportFile(wsPath, fn, expandedFile string) (executable.ExecutableList, error) {
+// importFunc generates the executables for an imported file.
+type importFunc func(wsPath, path string) (executable.ExecutableList, error)
+
+// findImporter returns the importer for an imported file, or nil if the file isn't handled by any importer. Importers
+// declared in the user's config take precedence over the built-in ones.
+func findImporter(fn, expandedFile string) importFunc {
+	if imp := findExternalImporter(expandedFile, true); imp != nil {
+		return imp.executables
+	}
 	switch strings.ToLower(fn) {
 	case "package.json":
-		return ExecutablesFromPackageJSON(wsPath, expandedFile)
+		return ExecutablesFromPackageJSON
 	case "makefile":
-		return ExecutablesFromMakefile(wsPath, expandedFile)
-	case "docker-compose.yml", "docker-compose.yaml":
-		return ExecutablesFromDockerCompose(wsPath, expandedFile)
-	default:
-		return parseScriptFile(wsPath, fn, expandedFile)
+		return ExecutablesFromMakefile
+	case "justfile", ".justfile":
+		return ExecutablesFromJustfile
+	case "taskfile.yml", "taskfile.yaml", "taskfile.dist.yml", "taskfile.dist.yaml":
+		return ExecutablesFromTaskfile
+	case "tasks.json":
+		return ExecutablesFromVSCodeTasks
+	case "pyproject.toml":
+		return ExecutablesFromPyproject
 	}
+	switch {
+	case isComposeFile(fn):
+		return ExecutablesFromDockerCompose
+	case isGitHubWorkflow(expandedFile):
+		return ExecutablesFromGitHubWorkflow
+	case isOpenAPIFile(fn):
+		return ExecutablesFromOpenAPI
+	case isHTTPFile(fn):
+		return ExecutablesFromHTTPFile
+	}
+	if imp := findExternalImporter(expandedFile, false); imp != nil {
+		return imp.executables
+	}
+	return scriptFileImporter(fn)
 }
 
-func parseScriptFile(wsPath, fn, expandedFile string) (executable.ExecutableList, error) {
-	ext := strings.ToLower(filepath.Ext(fn))
-	var exec *executable.Executable
-	var err error
-	switch ext {
+// scriptFileImporter returns the importer for a script file, or nil if the file isn't a supported script.
+func scriptFileImporter(fn string) importFunc {
+	var fromFile func(wsPath, path string) (*executable.Executable, error)
+	switch strings.ToLower(filepath.Ext(fn)) {
 	case ".sh":
-		exec, err = ExecutablesFromShFile(wsPath, expandedFile)
+		fromFile = ExecutablesFromShFile
 	case ".bat", ".cmd":
-		exec, err = ExecutablesFromBatFile(wsPath, expandedFile)
+		fromFile = ExecutablesFromBatFile
 	case ".ps1":
-		exec, err = ExecutablesFromPs1File(wsPath, expandedFile)
+		fromFile = ExecutablesFromPs1File
 	default:
-		logger.Log().Warn("unable to import executables - unsupported file type", "file", fn)
-		return nil, nil
+		return nil
 	}
-	if err != nil {
-		return nil, err
+	return func(wsPath, path string) (executable.ExecutableList, error) {
+		exec, err := fromFile(wsPath, path)
+		if err != nil {
+			return nil, err
+		}
+		return executable.ExecutableList{exec}, nil
+	}
+}
+
+// qualifyGeneratedRefs resolves the refs of a generated serial or parallel executable against the importing flow
+// file. Generated refs point at executables generated from the same file, so their namespace (if any) is relative
+// to the flow file's namespace.
+func qualifyGeneratedRefs(e *executable.Executable, flowFileNs string) {
+	qualify := func(ref executable.Ref) executable.Ref {
+		if ref == "" {
+			return ref
+		}
+		ws, ns, name := executable.MustParseExecutableID(ref.ID())
+		if ws != executable.WildcardWorkspace {
+			return ref
+		}
+		if ns == executable.WildcardNamespace {
+			ns = ""
+		}
+		return executable.NewRef(
+			executable.NewExecutableID(e.Workspace(), joinNamespace(flowFileNs, ns), name), ref.Verb(),
+		)
+	}
+	switch {
+	case e.Serial != nil:
+		for i := range e.Serial.Execs {
+			e.Serial.Execs[i].Ref = qualify(e.Serial.Execs[i].Ref)
+		}
+	case e.Parallel != nil:
+		for i := range e.Parallel.Execs {
+			e.Parallel.Execs[i].Ref = qualify(e.Parallel.Execs[i].Ref)
+		}
+	}
+}
+
+// parallelDepsExecutable returns an internal executable that runs the dependencies of a generated executable in
+// parallel, along with a ref to it. Serial executables can't run steps in parallel, so generated executables that run
+// their dependencies in parallel before their own command reference it as their first step.
+func parallelDepsExecutable(
+	e *executable.Executable, description string, dir executable.Directory, execs executable.ParallelRefConfigList,
+) (*executable.Executable, executable.Ref) {
+	internal := executable.ExecutableVisibility(common.VisibilityInternal)
+	deps := &executable.Executable{
+		Verb:        e.Verb,
+		Name:        strings.TrimPrefix(e.Name+"-deps", "-"),
+		Description: description,
+		Tags:        e.Tags,
+		Visibility:  &internal,
+		Parallel:    &executable.ParallelExecutableType{Dir: dir, Execs: execs},
+	}
+	ref := executable.NewRef(deps.Name, deps.Verb)
+	if ns := e.Namespace(); ns != "" {
+		deps.SetContext("", "", ns, "")
+		ref = executable.NewRef(ns+":"+deps.Name, deps.Verb)
+	}
+	return deps, ref
+}
+
+func joinNamespace(parent, ns string) string {
+	switch {
+	case parent == "":
+		return ns
+	case ns == "":
+		return parent
+	default:
+		return parent + "." + ns
+	}
+}
+
+// Env keys that would clobber the environment an imported command runs in if an argument was mapped onto them.
+var reservedEnvKeys = []string{"PATH", "HOME", "SHELL", "USER", "PWD", "TERM", "TMPDIR", "LANG"}
+
+// importArgEnvKey returns the env key for an argument of an imported command. Keys that would clobber the
+// environment are given the prefix.
+func importArgEnvKey(name, prefix string) string {
+	envKey := strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+	for _, reserved := range reservedEnvKeys {
+		if envKey == reserved {
+			return prefix + envKey
+		}
 	}
-	return executable.ExecutableList{exec}, nil
+	return envKey
 }
 
 func shortenWsPath(wsPath string, path string) string {
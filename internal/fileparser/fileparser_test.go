@@ -1,6 +1,7 @@
 package fileparser_test
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -20,6 +21,17 @@ func TestFileParser(t *testing.T) {
 	RunSpecs(t, "FileParser Suite")
 }
 
+// findExecutable returns the executable with the verb and name in the namespace, failing the spec when it isn't found.
+func findExecutable(execs executable.ExecutableList, verb executable.Verb, ns, name string) *executable.Executable {
+	for _, e := range execs {
+		if e.Verb == verb && e.Namespace() == ns && e.Name == name {
+			return e
+		}
+	}
+	Fail(fmt.Sprintf("executable not found: %s %s:%s", verb, ns, name))
+	return nil
+}
+
 var _ = Describe("ExecutablesFromImports", func() {
 	var (
 		ctrl       *gomock.Controller
@@ -59,6 +71,45 @@ var _ = Describe("ExecutablesFromImports", func() {
 		}
 	})
 
+	It("should scope generated refs to the flow file's namespace", func() {
+		flowFile.Namespace = "tools"
+		flowFile.Imports = append(flowFile.Imports, "justfile")
+		result, err := fileparser.ExecutablesFromImports("ws", flowFile)
+		Expect(err).NotTo(HaveOccurred())
+
+		test, err := result.FindByVerbAndID(executable.VerbTest, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(test.Serial.Execs[0].Ref).To(Equal(executable.Ref("build ws/tools:")))
+	})
+
+	It("should nest included Taskfile namespaces in the flow file's namespace", func() {
+		flowFile.Namespace = "tools"
+		flowFile.Imports = append(flowFile.Imports, "taskfile/Taskfile.yml")
+		result, err := fileparser.ExecutablesFromImports("ws", flowFile)
+		Expect(err).NotTo(HaveOccurred())
+
+		var publish *executable.Executable
+		for _, e := range result {
+			if e.Verb == executable.VerbPublish && e.Name == "" {
+				publish = e
+			}
+		}
+		Expect(publish).NotTo(BeNil())
+		Expect(publish.Namespace()).To(Equal("tools.docs"))
+		Expect(publish.Serial.Execs[0].Ref).To(Equal(executable.Ref("publish ws/tools.docs:deps")))
+	})
+
+	It("should return request executables from OpenAPI spec imports", func() {
+		flowFile.Imports = append(flowFile.Imports, "openapi/openapi.yaml")
+		result, err := fileparser.ExecutablesFromImports("ws", flowFile)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(HaveLen(4))
+		for _, e := range result {
+			Expect(e.Request).NotTo(BeNil())
+			Expect(e.Tags).To(ContainElement("openapi"))
+		}
+	})
+
 	It("should return executables from bat file imports", func() {
 		flowFile.Imports = append(flowFile.Imports, "simple.bat")
 		result, err := fileparser.ExecutablesFromImports("ws", flowFile)
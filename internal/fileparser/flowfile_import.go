@@ -0,0 +1,159 @@
+package fileparser
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/flowexec/flow/v2/internal/services/git"
+	"github.com/flowexec/flow/v2/internal/utils"
+	"github.com/flowexec/flow/v2/pkg/filesystem"
+	"github.com/flowexec/flow/v2/pkg/logger"
+	"github.com/flowexec/flow/v2/types/executable"
+)
+
+// gitImportPattern matches imports of a flow file pinned in a git repository, in the form
+// <repo-url>.git//<path>?ref=<tag or branch>.
+var gitImportPattern = regexp.MustCompile(`^(.+\.git)//([^?]+)\?ref=(.+)$`)
+
+// flowFileSchemaPattern matches the comment that YAML files use to declare the flow file schema, e.g.
+// `# yaml-language-server: $schema=https://flowexec.io/schemas/flowfile_schema.json`.
+var flowFileSchemaPattern = regexp.MustCompile(`^#\s*yaml-language-server:\s*\$schema=\S*flowfile_schema\.json$`)
+
+func parseGitImport(file string) (repo, path, ref string, ok bool) {
+	matches := gitImportPattern.FindStringSubmatch(file)
+	if matches == nil || !git.IsGitURL(matches[1]) {
+		return "", "", "", false
+	}
+	return matches[1], matches[2], matches[3], true
+}
+
+// gitImportPath returns the local path of a flow file imported from a git repository. The ref is resolved to a
+// commit, which is cloned into the flow cache the first time it's used. Unless update is set, the commit that the ref
+// last resolved to is used without reaching the remote.
+func gitImportPath(repo, path, ref string, update bool) (string, error) {
+	commit, err := git.ResolveRef(repo, ref, update)
+	if err != nil {
+		return "", err
+	}
+	cloneDir, _, err := git.EnsureCommitClone(repo, ref, commit)
+	if err != nil {
+		return "", err
+	}
+	localPath := filepath.Join(cloneDir, filepath.FromSlash(path))
+	if rel, err := filepath.Rel(cloneDir, localPath); err != nil || strings.HasPrefix(rel, "..") {
+		return "", fmt.Errorf("%s is outside of the repository", path)
+	}
+	return localPath, nil
+}
+
+// expandImportPaths returns the files matched by an import. Imports containing glob patterns can match any number
+// of files, but never the importing flow file itself.
+func expandImportPaths(file, flowFilePath string) []string {
+	expandedFile := utils.ExpandPath(file, filepath.Dir(flowFilePath), nil)
+	if !strings.ContainsAny(file, "*?[") {
+		return []string{expandedFile}
+	}
+
+	matches, err := filepath.Glob(expandedFile)
+	if err != nil {
+		logger.Log().Error("unable to import executables", "pattern", file, "err", err)
+		return nil
+	}
+	if len(matches) == 0 {
+		logger.Log().Warn("no files matched import pattern", "pattern", file)
+	}
+	paths := make([]string, 0, len(matches))
+	for _, m := range matches {
+		if m != flowFilePath {
+			paths = append(paths, m)
+		}
+	}
+	return paths
+}
+
+// isFlowFileImport reports whether an imported YAML file without a flow file extension opts in to being included as
+// a flow file by declaring the flow file schema in a yaml-language-server comment before its content.
+func isFlowFileImport(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext != ".yaml" && ext != ".yml" {
+		return false
+	}
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "" || line == "---":
+			continue
+		case !strings.HasPrefix(line, "#"):
+			return false
+		case flowFileSchemaPattern.MatchString(line):
+			return true
+		}
+	}
+	return false
+}
+
+// executablesFromFlowFile loads the executables of a flow file included by parent. The included file's namespace
+// and visibility default to the parent's, and its own imports are included as well.
+func executablesFromFlowFile(
+	wsName string, parent *executable.FlowFile, path string, state *importState,
+) (executable.ExecutableList, error) {
+	if state.including[path] {
+		return nil, fmt.Errorf("include cycle detected at %s", path)
+	}
+	state.including[path] = true
+	defer delete(state.including, path)
+
+	included, err := filesystem.LoadFlowFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if included.Namespace == "" {
+		included.Namespace = parent.Namespace
+	}
+	if included.Visibility == nil {
+		included.Visibility = parent.Visibility
+	}
+	included.SetDefaults()
+	included.SetContext(wsName, parent.WorkspacePath(), path)
+
+	nested, err := executablesFromImports(wsName, included, state)
+	if err != nil {
+		return nil, err
+	}
+	return appendIncluded(included, included.Executables, nested), nil
+}
+
+// appendIncluded appends the included executables to list, skipping any whose reference is already defined by
+// the flow file or an earlier import. The flow file's own executables always take precedence.
+func appendIncluded(
+	flowFile *executable.FlowFile, list, included executable.ExecutableList,
+) executable.ExecutableList {
+	sameRef := func(e *executable.Executable) func(*executable.Executable) bool {
+		return func(other *executable.Executable) bool { return other.Ref() == e.Ref() }
+	}
+	for _, e := range included {
+		if slices.ContainsFunc(flowFile.Executables, sameRef(e)) || slices.ContainsFunc(list, sameRef(e)) {
+			logger.Log().Warn(
+				"duplicate executable found in included flow file",
+				"ref", e.Ref().String(),
+				"includedPath", e.FlowFilePath(),
+				"flowFilePath", flowFile.ConfigPath(),
+			)
+			continue
+		}
+		list = append(list, e)
+	}
+	return list
+}
@@ -0,0 +1,167 @@
+package fileparser_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/flowexec/tuikit/io/mocks"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/flowexec/flow/v2/internal/fileparser"
+	"github.com/flowexec/flow/v2/pkg/filesystem"
+	"github.com/flowexec/flow/v2/pkg/logger"
+	"github.com/flowexec/flow/v2/types/executable"
+)
+
+var _ = Describe("Flow file imports", func() {
+	var (
+		mockLogger *mocks.MockLogger
+		flowFile   *executable.FlowFile
+		wsPath     string
+	)
+
+	BeforeEach(func() {
+		mockLogger = mocks.NewMockLogger(gomock.NewController(GinkgoT()))
+		logger.Init(logger.InitOptions{Logger: mockLogger, TestingTB: GinkgoTB()})
+
+		wd, err := os.Getwd()
+		Expect(err).ToNot(HaveOccurred())
+		wsPath = filepath.Join(wd, "testdata")
+		flowFile = &executable.FlowFile{Namespace: "tools"}
+		flowFile.SetDefaults()
+		flowFile.SetContext("ws", wsPath, filepath.Join(wsPath, "test"+executable.FlowFileExt))
+	})
+
+	It("should include executables from another flow file with the including namespace", func() {
+		flowFile.Imports = executable.Imports{"included/tools.yaml"}
+		result, err := fileparser.ExecutablesFromImports("ws", flowFile)
+		Expect(err).NotTo(HaveOccurred())
+
+		refs := make([]string, 0, len(result))
+		for _, e := range result {
+			refs = append(refs, e.Ref().String())
+		}
+		Expect(refs).To(ConsistOf("build ws/tools:app", "lint ws/tools:app", "show ws/tools:hello"))
+		Expect(result[0].Tags).To(ContainElement("shared"))
+		Expect(result[0].FlowFilePath()).To(Equal(filepath.Join(wsPath, "included", "tools.yaml")))
+	})
+
+	It("should include every flow file matched by a glob", func() {
+		mockLogger.EXPECT().WrapError(gomock.Any(), gomock.Any()).Times(1) // cycle.yaml
+		flowFile.Imports = executable.Imports{"included/*.yaml"}
+		result, err := fileparser.ExecutablesFromImports("ws", flowFile)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.FilterByNamespace("other")).To(HaveLen(2))
+		Expect(result.FilterByNamespace("tools")).To(HaveLen(4))
+	})
+
+	It("should keep the flow file's own executables when an included one conflicts", func() {
+		flowFile.Executables = executable.ExecutableList{
+			{Verb: "build", Name: "app", Exec: &executable.ExecExecutableType{Cmd: "echo own"}},
+		}
+		flowFile.SetContext("ws", wsPath, flowFile.ConfigPath())
+		mockLogger.EXPECT().Warn(
+			"duplicate executable found in included flow file",
+			"ref", "build ws/tools:app", "includedPath", gomock.Any(), "flowFilePath", flowFile.ConfigPath(),
+		).Times(1)
+
+		flowFile.Imports = executable.Imports{"included/tools.yaml"}
+		result, err := fileparser.ExecutablesFromImports("ws", flowFile)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(HaveLen(2))
+	})
+
+	It("should only include YAML files that declare the flow file schema", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "config.yaml")
+		Expect(os.WriteFile(path, []byte("executables:\n  - verb: run\n    exec:\n      cmd: echo\n"), 0600)).
+			To(Succeed())
+		mockLogger.EXPECT().Warn("unable to import executables - unsupported file type", "file", "config.yaml").Times(1)
+
+		flowFile.Imports = executable.Imports{path}
+		result, err := fileparser.ExecutablesFromImports("ws", flowFile)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(BeEmpty())
+	})
+
+	It("should report include cycles", func() {
+		mockLogger.EXPECT().WrapError(gomock.Any(), gomock.Any()).Times(1)
+		flowFile.Imports = executable.Imports{"included/cycle.yaml"}
+		result, err := fileparser.ExecutablesFromImports("ws", flowFile)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(HaveLen(1))
+	})
+
+	Context("from git", func() {
+		var (
+			tmpDir  string
+			repoDir string
+			content []byte
+		)
+
+		runGit := func(args ...string) {
+			cmd := exec.Command("git", args...)
+			cmd.Dir = repoDir
+			out, err := cmd.CombinedOutput()
+			Expect(err).NotTo(HaveOccurred(), string(out))
+		}
+
+		BeforeEach(func() {
+			if _, err := exec.LookPath("git"); err != nil {
+				Skip("git is not installed")
+			}
+			tmpDir = GinkgoT().TempDir()
+			GinkgoT().Setenv(filesystem.FlowCacheDirEnvVar, filepath.Join(tmpDir, "cache"))
+
+			repoDir = filepath.Join(tmpDir, "repo")
+			Expect(os.MkdirAll(filepath.Join(repoDir, "flows"), 0750)).To(Succeed())
+			var err error
+			content, err = os.ReadFile(filepath.Join(wsPath, "included", "other.yaml"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(os.WriteFile(filepath.Join(repoDir, "flows", "shared.yaml"), content, 0600)).To(Succeed())
+			runGit("init", "-q", "-b", "main")
+			runGit("add", ".")
+			runGit("-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "init")
+			runGit("tag", "v1.0.0")
+			runGit("clone", "-q", "--bare", repoDir, filepath.Join(tmpDir, "shared.git"))
+		})
+
+		It("should include flow files from a pinned git ref", func() {
+			flowFile.Imports = executable.Imports{
+				"file://" + filepath.Join(tmpDir, "shared.git") + "//flows/shared.yaml?ref=v1.0.0",
+			}
+			result, err := fileparser.ExecutablesFromImports("ws", flowFile)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(HaveLen(2))
+
+			// The resolved commit is reused without reaching the remote again.
+			Expect(os.RemoveAll(filepath.Join(tmpDir, "shared.git"))).To(Succeed())
+			result, err = fileparser.SyncExecutablesFromImports("ws", flowFile)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(HaveLen(2))
+		})
+
+		It("should pick up new commits of a branch when syncing", func() {
+			flowFile.Imports = executable.Imports{
+				"file://" + filepath.Join(tmpDir, "shared.git") + "//flows/shared.yaml?ref=main",
+			}
+			result, err := fileparser.ExecutablesFromImports("ws", flowFile)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(HaveLen(2))
+
+			content = append(content, []byte("  - verb: run\n    name: app\n    exec:\n      cmd: go run .\n")...)
+			Expect(os.WriteFile(filepath.Join(repoDir, "flows", "shared.yaml"), content, 0600)).To(Succeed())
+			runGit("-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-am", "add run")
+			runGit("push", "-q", filepath.Join(tmpDir, "shared.git"), "main")
+
+			result, err = fileparser.ExecutablesFromImports("ws", flowFile)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(HaveLen(2))
+			result, err = fileparser.SyncExecutablesFromImports("ws", flowFile)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(HaveLen(3))
+		})
+	})
+})
@@ -0,0 +1,466 @@
+package fileparser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/flowexec/flow/v2/types/executable"
+)
+
+type ghWorkflow struct {
+	Name     string            `yaml:"name"`
+	On       yaml.Node         `yaml:"on"`
+	Env      map[string]string `yaml:"env"`
+	Defaults ghDefaults        `yaml:"defaults"`
+	Jobs     map[string]ghJob  `yaml:"jobs"`
+}
+
+type ghDefaults struct {
+	Run struct {
+		Shell            string `yaml:"shell"`
+		WorkingDirectory string `yaml:"working-directory"`
+	} `yaml:"run"`
+}
+
+type ghJob struct {
+	Name     string            `yaml:"name"`
+	If       string            `yaml:"if"`
+	Uses     string            `yaml:"uses"`
+	Env      map[string]string `yaml:"env"`
+	Defaults ghDefaults        `yaml:"defaults"`
+	Strategy struct {
+		Matrix map[string]any `yaml:"matrix"`
+	} `yaml:"strategy"`
+	Steps []ghStep `yaml:"steps"`
+}
+
+type ghStep struct {
+	ID               string            `yaml:"id"`
+	Name             string            `yaml:"name"`
+	If               string            `yaml:"if"`
+	Uses             string            `yaml:"uses"`
+	Run              string            `yaml:"run"`
+	Shell            string            `yaml:"shell"`
+	WorkingDirectory string            `yaml:"working-directory"`
+	Env              map[string]string `yaml:"env"`
+}
+
+const ghNamespace = "ci"
+
+var (
+	ghTags = []string{generatedTag, "github-actions"}
+
+	// ghShells are the commands that GitHub Actions runs a step's script file with, by the step's `shell`.
+	ghShells = map[string]struct{ template, ext string }{
+		"bash":       {"bash --noprofile --norc -eo pipefail {0}", ".sh"},
+		"sh":         {"sh -e {0}", ".sh"},
+		"python":     {"python {0}", ".py"},
+		"pwsh":       {`pwsh -command ". '{0}'"`, ".ps1"},
+		"powershell": {`powershell -command ". '{0}'"`, ".ps1"},
+		"cmd":        {`cmd /D /E:ON /V:OFF /S /C "CALL "{0}""`, ".cmd"},
+	}
+
+	// e.g. "${{ env.VERSION }}", "${{ secrets.TOKEN }}"
+	ghExpression = regexp.MustCompile(`\$\{\{\s*(.*?)\s*\}\}`)
+	// e.g. "env.VERSION", "matrix.go-version"
+	ghContextRef = regexp.MustCompile(`^(env|vars|secrets|inputs|matrix)\.([a-zA-Z_][a-zA-Z0-9_-]*)$`)
+	// ghArgPrefixes are the prefixes of the env keys for the contexts whose values are passed as args.
+	ghArgPrefixes = map[string]string{"inputs": "INPUT_", "matrix": "MATRIX_"}
+	ghIfToken     = regexp.MustCompile(
+		`\s+|'(?:[^']|'')*'|==|!=|<=|>=|&&|\|\||[()!<>]|[a-zA-Z_][a-zA-Z0-9_.-]*(?:\(\))?|\d+(?:\.\d+)?`,
+	)
+)
+
+// ExecutablesFromGitHubWorkflow parses a GitHub Actions workflow and returns a serial Executable for each of its jobs,
+// in the `ci` namespace. The `run:` steps of a job are run in order with their working directory, shell and env, while
+// steps that use an action, or that have an `if:` condition that can't be evaluated locally, are skipped.
+func ExecutablesFromGitHubWorkflow(wsPath, path string) (executable.ExecutableList, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open workflow file: %w", err)
+	}
+	defer f.Close()
+
+	var wf ghWorkflow
+	if err := yaml.NewDecoder(f).Decode(&wf); err != nil {
+		return nil, fmt.Errorf("failed to decode workflow file: %w", err)
+	}
+
+	// Workflows live in .github/workflows, and their steps run from the repository root.
+	root := filepath.Dir(filepath.Dir(filepath.Dir(path)))
+	inputs := ghDispatchInputs(&wf.On)
+	execs := make(executable.ExecutableList, 0, len(wf.Jobs))
+	for _, id := range sortedKeys(wf.Jobs) {
+		job := wf.Jobs[id]
+		// Jobs that call a reusable workflow have no steps of their own.
+		if job.Uses != "" {
+			continue
+		}
+		t := &ghTranslator{
+			wsPath: wsPath,
+			root:   root,
+			inputs: inputs,
+			matrix: job.Strategy.Matrix,
+			args:   make(map[string]executable.Argument),
+		}
+		if e := t.jobExecutable(&wf, id, job); e != nil {
+			execs = append(execs, e)
+		}
+	}
+	return execs, nil
+}
+
+// isGitHubWorkflow reports whether path is a workflow file in a .github/workflows directory.
+func isGitHubWorkflow(path string) bool {
+	dir := filepath.Dir(path)
+	ext := strings.ToLower(filepath.Ext(path))
+	return (ext == ".yml" || ext == ".yaml") &&
+		filepath.Base(dir) == "workflows" && filepath.Base(filepath.Dir(dir)) == ".github"
+}
+
+// ghTranslator translates the expressions of a job into their flow equivalents, collecting the args for the inputs
+// and matrix values that they reference.
+type ghTranslator struct {
+	wsPath string
+	root   string
+	inputs map[string]string
+	matrix map[string]any
+	args   map[string]executable.Argument
+	notes  []string
+}
+
+//nolint:gocognit
+func (t *ghTranslator) jobExecutable(wf *ghWorkflow, id string, job ghJob) *executable.Executable {
+	jobIf, ok := t.condition(job.If)
+	if !ok {
+		t.notes = append(t.notes, fmt.Sprintf("The job's condition `%s` is ignored", job.If))
+		jobIf = ""
+	}
+
+	env := make(map[string]string, len(wf.Env)+len(job.Env))
+	for k, v := range wf.Env {
+		env[k] = v
+	}
+	for k, v := range job.Env {
+		env[k] = v
+	}
+	// Env values with expressions are exported by each step so that they're expanded by the shell.
+	params := make(executable.ParameterList, 0, len(env))
+	exprEnv := make(map[string]string)
+	for _, k := range sortedKeys(env) {
+		if ghExpression.MatchString(env[k]) {
+			exprEnv[k] = env[k]
+			continue
+		}
+		params = append(params, executable.Parameter{EnvKey: k, Text: env[k]})
+	}
+
+	steps := make(executable.SerialRefConfigList, 0, len(job.Steps))
+	var runs int
+	for i, step := range job.Steps {
+		label := ghStepLabel(step, i)
+		if step.Run == "" {
+			if step.Uses != "" {
+				t.notes = append(t.notes, fmt.Sprintf("Skipped step %s (uses %s)", label, step.Uses))
+				steps = append(steps, executable.SerialRefConfig{
+					Name: label,
+					Cmd:  ghEcho(fmt.Sprintf("Skipping step %s: uses %s", label, step.Uses)),
+				})
+			}
+			continue
+		}
+		stepIf, ok := t.condition(step.If)
+		if !ok {
+			t.notes = append(t.notes, fmt.Sprintf("Skipped step %s (if: %s)", label, step.If))
+			steps = append(steps, executable.SerialRefConfig{
+				Name: label,
+				Cmd:  ghEcho(fmt.Sprintf("Skipping step %s: its condition cannot be evaluated locally", label)),
+			})
+			continue
+		}
+		runs++
+		steps = append(steps, executable.SerialRefConfig{
+			Name: label,
+			If:   ghJoinConditions(jobIf, stepIf),
+			Cmd:  t.stepCmd(wf, job, step, exprEnv),
+		})
+	}
+	if runs == 0 {
+		return nil
+	}
+
+	description := fmt.Sprintf("Run the steps of GitHub Actions job %s", id)
+	if job.Name != "" {
+		description = fmt.Sprintf("Run the steps of GitHub Actions job %s (%s)", id, job.Name)
+	}
+	if wf.Name != "" {
+		description += fmt.Sprintf(" in workflow %s", wf.Name)
+	}
+	if len(t.notes) > 0 {
+		description += "\n\n- " + strings.Join(t.notes, "\n- ")
+	}
+
+	args := make(executable.ArgumentList, 0, len(t.args))
+	for _, k := range sortedKeys(t.args) {
+		args = append(args, t.args[k])
+	}
+	e := &executable.Executable{
+		Verb:        executable.VerbExec,
+		Name:        NormalizeName(id, ""),
+		Description: description,
+		Tags:        ghTags,
+		Serial: &executable.SerialExecutableType{
+			Dir:    executable.Directory(shortenWsPath(t.wsPath, t.root)),
+			Args:   args,
+			Params: params,
+			Execs:  steps,
+		},
+	}
+	e.SetContext("", "", ghNamespace, "")
+	return e
+}
+
+// stepCmd returns the command that runs a step's script with its env, working directory and shell. Scripts without a
+// shell are run by flow's shell, while the others are written to a file that the shell's command runs.
+func (t *ghTranslator) stepCmd(wf *ghWorkflow, job ghJob, step ghStep, exprEnv map[string]string) string {
+	var lines []string
+	env := make(map[string]string, len(exprEnv)+len(step.Env))
+	for k, v := range exprEnv {
+		env[k] = v
+	}
+	for k, v := range step.Env {
+		env[k] = v
+	}
+	for _, k := range sortedKeys(env) {
+		lines = append(lines, fmt.Sprintf(`export %s="%s"`, k, t.expand(env[k], `\"$`+"`")))
+	}
+
+	wd := firstNonEmpty(step.WorkingDirectory, job.Defaults.Run.WorkingDirectory, wf.Defaults.Run.WorkingDirectory)
+	if wd != "" {
+		lines = append(lines, fmt.Sprintf(`cd "%s"`, t.expand(wd, `\"$`+"`")))
+	}
+
+	script := strings.TrimRight(step.Run, "\n")
+	shell := firstNonEmpty(step.Shell, job.Defaults.Run.Shell, wf.Defaults.Run.Shell)
+	if shell == "" {
+		// GitHub Actions stops a step's script at the first failing command.
+		if strings.Contains(script, "\n") {
+			lines = append(lines, "set -e")
+		}
+		lines = append(lines, t.expand(script, ""))
+		return strings.Join(lines, "\n")
+	}
+
+	template, ext := shell+" {0}", ""
+	if s, found := ghShells[shell]; found {
+		template, ext = s.template, s.ext
+	} else if strings.Contains(shell, "{0}") {
+		template = shell
+	}
+	lines = append(lines,
+		fmt.Sprintf(`flow_step="$(mktemp -d)/step%s"`, ext),
+		`cat > "$flow_step" <<FLOW_STEP`,
+		t.expand(script, `\$`+"`"),
+		"FLOW_STEP",
+		strings.ReplaceAll(template, "{0}", "$flow_step"),
+	)
+	return strings.Join(lines, "\n")
+}
+
+// expand replaces the expressions in s with references to the environment, escaping the characters in escape that
+// the shell would otherwise expand.
+func (t *ghTranslator) expand(s, escape string) string {
+	var out strings.Builder
+	last := 0
+	for _, m := range ghExpression.FindAllStringSubmatchIndex(s, -1) {
+		out.WriteString(ghEscape(s[last:m[0]], escape))
+		out.WriteString(t.expression(s[m[2]:m[3]]))
+		last = m[1]
+	}
+	out.WriteString(ghEscape(s[last:], escape))
+	return out.String()
+}
+
+// expression returns the shell equivalent of an expression. Expressions that can't be evaluated locally are noted in
+// the executable's description and expand to nothing.
+func (t *ghTranslator) expression(expr string) string {
+	if key, ok := t.contextEnvKey(expr); ok {
+		return fmt.Sprintf("${%s}", key)
+	}
+	switch expr {
+	case "github.workspace":
+		if t.wsPath != "" && strings.HasPrefix(t.root, t.wsPath) {
+			return "${FLOW_WORKSPACE_PATH}" + strings.TrimPrefix(t.root, t.wsPath)
+		}
+		return t.root
+	case "runner.temp":
+		return "${TMPDIR:-/tmp}"
+	}
+	note := fmt.Sprintf("The expression `%s` can't be evaluated locally and is left empty", expr)
+	for _, n := range t.notes {
+		if n == note {
+			return ""
+		}
+	}
+	t.notes = append(t.notes, note)
+	return ""
+}
+
+// contextEnvKey returns the env key that holds the value of a context reference (e.g. `env.VERSION`). Inputs and
+// matrix values are given an arg.
+func (t *ghTranslator) contextEnvKey(expr string) (string, bool) {
+	m := ghContextRef.FindStringSubmatch(expr)
+	if m == nil {
+		return "", false
+	}
+	prefix, found := ghArgPrefixes[m[1]]
+	if !found {
+		return m[2], true
+	}
+	key := prefix + strings.ToUpper(strings.ReplaceAll(m[2], "-", "_"))
+	if _, found := t.args[key]; !found {
+		arg := executable.Argument{EnvKey: key, Flag: strings.ToLower(strings.ReplaceAll(key, "_", "-"))}
+		if m[1] == "inputs" {
+			arg.Default = t.inputs[m[2]]
+		} else if values, ok := t.matrix[m[2]].([]any); ok && len(values) > 0 {
+			arg.Default = fmt.Sprintf("%v", values[0])
+		}
+		t.args[key] = arg
+	}
+	return key, true
+}
+
+// condition translates a simple `if:` expression into an Expr condition. Conditions that reference contexts other than
+// the environment, inputs, matrix and runner, or that call functions other than `success()` and `always()`, can't be
+// translated.
+func (t *ghTranslator) condition(cond string) (string, bool) {
+	cond = strings.TrimSpace(cond)
+	if m := ghExpression.FindStringSubmatch(cond); m != nil && m[0] == cond {
+		cond = m[1]
+	}
+	if cond == "" {
+		return "", true
+	}
+
+	var out strings.Builder
+	last := 0
+	for _, loc := range ghIfToken.FindAllStringIndex(cond, -1) {
+		if loc[0] != last {
+			return "", false
+		}
+		last = loc[1]
+		tok := cond[loc[0]:loc[1]]
+		switch {
+		case strings.TrimSpace(tok) == "":
+			out.WriteString(" ")
+		case strings.HasPrefix(tok, "'"):
+			out.WriteString(strconv.Quote(strings.ReplaceAll(tok[1:len(tok)-1], "''", "'")))
+		case tok == "success()" || tok == "always()":
+			out.WriteString("true")
+		case tok == "null":
+			out.WriteString("nil")
+		case tok == "runner.os":
+			out.WriteString(`(os == "darwin" ? "macOS" : os == "windows" ? "Windows" : "Linux")`)
+		case tok == "runner.arch":
+			out.WriteString(`(arch == "arm64" ? "ARM64" : "X64")`)
+		case ghContextRef.MatchString(tok):
+			key, _ := t.contextEnvKey(tok)
+			out.WriteString(fmt.Sprintf("env[%q]", key))
+		case strings.ContainsAny(tok[:1], "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ_"):
+			if tok != "true" && tok != "false" {
+				return "", false
+			}
+			out.WriteString(tok)
+		default:
+			out.WriteString(tok)
+		}
+	}
+	if last != len(cond) {
+		return "", false
+	}
+	return strings.TrimSpace(out.String()), true
+}
+
+// ghDispatchInputs returns the default values of the workflow's `workflow_dispatch` inputs.
+func ghDispatchInputs(on *yaml.Node) map[string]string {
+	var triggers struct {
+		WorkflowDispatch struct {
+			Inputs map[string]struct {
+				Default string `yaml:"default"`
+			} `yaml:"inputs"`
+		} `yaml:"workflow_dispatch"`
+	}
+	defaults := make(map[string]string)
+	// Triggers can also be a single event name or a list of them.
+	if on.Kind != yaml.MappingNode || on.Decode(&triggers) != nil {
+		return defaults
+	}
+	for name, in := range triggers.WorkflowDispatch.Inputs {
+		defaults[name] = in.Default
+	}
+	return defaults
+}
+
+func ghStepLabel(step ghStep, i int) string {
+	switch {
+	case step.Name != "":
+		return step.Name
+	case step.ID != "":
+		return step.ID
+	case step.Uses != "":
+		return step.Uses
+	default:
+		return fmt.Sprintf("%d", i+1)
+	}
+}
+
+func ghJoinConditions(conds ...string) string {
+	var set []string
+	for _, c := range conds {
+		if c != "" && c != "true" {
+			set = append(set, c)
+		}
+	}
+	if len(set) == 1 {
+		return set[0]
+	}
+	parts := make([]string, 0, len(set))
+	for _, c := range set {
+		parts = append(parts, "("+c+")")
+	}
+	return strings.Join(parts, " && ")
+}
+
+// ghEcho returns a command that prints msg.
+func ghEcho(msg string) string {
+	return fmt.Sprintf("echo '%s'", strings.ReplaceAll(msg, "'", `'\''`))
+}
+
+func ghEscape(s, chars string) string {
+	if chars == "" {
+		return s
+	}
+	var out strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(chars, r) {
+			out.WriteRune('\\')
+		}
+		out.WriteRune(r)
+	}
+	return out.String()
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,85 @@
+package fileparser_test
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/flowexec/flow/v2/internal/fileparser"
+	"github.com/flowexec/flow/v2/types/executable"
+)
+
+var _ = Describe("ExecutablesFromGitHubWorkflow", func() {
+	var execs executable.ExecutableList
+
+	BeforeEach(func() {
+		wsPath, err := filepath.Abs("testdata/github")
+		Expect(err).NotTo(HaveOccurred())
+		execs, err = fileparser.ExecutablesFromGitHubWorkflow(
+			wsPath, filepath.Join(wsPath, ".github", "workflows", "ci.yml"),
+		)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should create a serial executable in the ci namespace for each job with run steps", func() {
+		Expect(execs).To(HaveLen(2))
+		for _, e := range execs {
+			Expect(e.Verb).To(Equal(executable.VerbExec))
+			Expect(e.Namespace()).To(Equal("ci"))
+			Expect(e.Tags).To(ConsistOf("generated", "github-actions"))
+			Expect(e.Serial).NotTo(BeNil())
+			Expect(e.Serial.Dir).To(Equal(executable.Directory("//")))
+		}
+		Expect(execs[0].Name).To(Equal("release"))
+		Expect(execs[1].Name).To(Equal("test"))
+	})
+
+	It("should skip steps that use actions with a note", func() {
+		test := execs[1]
+		Expect(test.Description).To(ContainSubstring("Run the steps of GitHub Actions job test (Unit tests) in workflow CI"))
+		Expect(test.Description).To(ContainSubstring("Skipped step actions/checkout@v4 (uses actions/checkout@v4)"))
+		Expect(test.Serial.Execs[1].Name).To(Equal("Set up Go"))
+		Expect(test.Serial.Execs[1].Cmd).To(Equal("echo 'Skipping step Set up Go: uses actions/setup-go@v5'"))
+	})
+
+	It("should map env, working directories and shells", func() {
+		test := execs[1]
+		Expect(test.Serial.Params).To(ConsistOf(executable.Parameter{EnvKey: "GO_FLAGS", Text: "-trimpath"}))
+		Expect(test.Serial.Execs[2].Cmd).To(Equal(
+			"export CACHE_DIR=\"${TMPDIR:-/tmp}/cache\"\n" +
+				"export LOG_LEVEL=\"${INPUT_LOG_LEVEL}\"\n" +
+				"export TOKEN=\"${API_TOKEN}\"\n" +
+				"cd \"src\"\n" +
+				"set -e\n" +
+				"go version\n" +
+				"go test $GO_FLAGS ./...",
+		))
+		Expect(test.Serial.Execs[5].Cmd).To(HaveSuffix(
+			"cd \"scripts\"\n" +
+				"flow_step=\"$(mktemp -d)/step.py\"\n" +
+				"cat > \"$flow_step\" <<FLOW_STEP\n" +
+				"print(\"${MATRIX_GO}: \\$HOME\")\n" +
+				"FLOW_STEP\n" +
+				"python $flow_step",
+		))
+		Expect(test.Serial.Args).To(ConsistOf(
+			executable.Argument{EnvKey: "INPUT_LOG_LEVEL", Flag: "input-log-level", Default: "info"},
+			executable.Argument{EnvKey: "MATRIX_GO", Flag: "matrix-go", Default: "1.24"},
+		))
+	})
+
+	It("should translate simple conditions", func() {
+		test := execs[1]
+		Expect(test.Serial.Execs[3].If).To(Equal(
+			`(os == "darwin" ? "macOS" : os == "windows" ? "Windows" : "Linux") == "Linux" && env["GO_FLAGS"] != ""`,
+		))
+		Expect(test.Serial.Execs[4].Cmd).To(ContainSubstring("its condition cannot be evaluated locally"))
+		Expect(test.Description).To(ContainSubstring("Skipped step Upload coverage (if: github.event_name == 'push')"))
+
+		release := execs[0]
+		Expect(release.Serial.Execs).To(HaveLen(1))
+		Expect(release.Serial.Execs[0].If).To(BeEmpty())
+		Expect(release.Serial.Execs[0].Cmd).To(HaveSuffix("bash --noprofile --norc -eo pipefail $flow_step"))
+	})
+})
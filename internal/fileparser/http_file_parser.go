@@ -0,0 +1,491 @@
+package fileparser
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/flowexec/flow/v2/types/common"
+	"github.com/flowexec/flow/v2/types/executable"
+)
+
+// httpRequest is a request of a `.http` file.
+type httpRequest struct {
+	name        string
+	description string
+	method      string
+	url         string
+	headers     map[string]string
+	body        string
+	noRedirect  bool
+}
+
+// httpRefs collects the variables that a request uses.
+type httpRefs struct {
+	// params are the file variables with static values, keyed by name.
+	params map[string]string
+	// deps are the names of the requests whose responses are used.
+	deps []string
+}
+
+// httpVariables converts the `{{variable}}` references of a `.http` file to environment variable references.
+type httpVariables struct {
+	file map[string]string
+	// requests maps the names of the file's requests to their executable names.
+	requests map[string]string
+	// captures holds the values that must be captured from the response of each request, keyed by request name and
+	// then by the key they're saved to.
+	captures map[string]map[string]string
+}
+
+var (
+	httpTags = []string{generatedTag, "http"}
+	// e.g. "{{baseUrl}}", "{{login.response.body.$.token}}", "{{$processEnv API_KEY}}"
+	httpVariable     = regexp.MustCompile(`\{\{\s*([^{}]*?)\s*\}\}`)
+	httpFileVariable = regexp.MustCompile(`^@([\w.-]+)\s*=\s*(.*)$`)
+	httpMetadata     = regexp.MustCompile(`^(?:#|//)\s*@([\w-]+)(?:\s*=?\s*(.*))?$`)
+	httpResponseRef  = regexp.MustCompile(`^([\w-]+)\.response\.(body|headers)(?:\.(.+))?$`)
+	httpIdentifier   = regexp.MustCompile(`^[A-Za-z_]\w*$`)
+	httpBodyFile     = regexp.MustCompile(`^<@?\w*\s+(\S+)$`)
+	httpMethods      = []string{
+		http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodHead, http.MethodOptions,
+	}
+)
+
+// ExecutablesFromHTTPFile parses a `.http` or `.rest` file (the VS Code REST Client and JetBrains HTTP Client format)
+// and returns a request Executable for each of its named requests. File variables become params, and requests that
+// use values from the responses of other requests run those requests first as a serial executable.
+func ExecutablesFromHTTPFile(wsPath, path string) (executable.ExecutableList, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open http file: %w", err)
+	}
+	requests, fileVars := parseHTTPFile(data)
+
+	vars := &httpVariables{
+		file:     fileVars,
+		requests: make(map[string]string, len(requests)),
+		captures: make(map[string]map[string]string),
+	}
+	for _, r := range requests {
+		vars.requests[r.name] = NormalizeName(r.name, "")
+	}
+
+	specs := make(map[string]*executable.RequestExecutableType, len(requests))
+	deps := make(map[string][]string, len(requests))
+	for _, r := range requests {
+		spec, refs, err := httpRequestSpec(r, vars, wsPath, filepath.Dir(path))
+		if err != nil {
+			return nil, err
+		}
+		specs[r.name] = spec
+		deps[r.name] = refs.deps
+	}
+	if err := checkHTTPChain(requests, deps); err != nil {
+		return nil, err
+	}
+
+	internal := executable.ExecutableVisibility(common.VisibilityInternal)
+	execs := make(executable.ExecutableList, 0, len(requests))
+	for _, r := range requests {
+		spec := specs[r.name]
+		spec.Capture = vars.captures[r.name]
+		e := &executable.Executable{
+			Verb:        executable.VerbRequest,
+			Name:        vars.requests[r.name],
+			Description: r.description,
+			Tags:        httpTags,
+		}
+		requestDeps := directHTTPDeps(r.name, deps)
+		if len(requestDeps) == 0 {
+			e.Request = spec
+			execs = append(execs, e)
+			continue
+		}
+
+		// The requests whose responses are used are sent first, in the same process, so that their captured values
+		// are available to the request.
+		send := &executable.Executable{
+			Verb:        executable.VerbRequest,
+			Name:        e.Name + "-send",
+			Description: r.description,
+			Tags:        httpTags,
+			Visibility:  &internal,
+			Request:     spec,
+		}
+		steps := make(executable.SerialRefConfigList, 0, len(requestDeps)+1)
+		for _, dep := range requestDeps {
+			steps = append(steps, executable.SerialRefConfig{
+				Ref: executable.NewRef(vars.requests[dep], executable.VerbRequest),
+			})
+		}
+		steps = append(steps, executable.SerialRefConfig{Ref: executable.NewRef(send.Name, executable.VerbRequest)})
+		e.Serial = &executable.SerialExecutableType{Params: spec.Params, Execs: steps}
+		execs = append(execs, e, send)
+	}
+	return execs, nil
+}
+
+// parseHTTPFile returns the named requests and the file variables of a `.http` file. Requests are separated by
+// `###` lines, where the text after the separator is used as the request's description.
+func parseHTTPFile(data []byte) ([]*httpRequest, map[string]string) {
+	var requests []*httpRequest
+	fileVars := make(map[string]string)
+
+	var block []string
+	title := ""
+	flush := func() {
+		if r := parseHTTPRequest(block, title, fileVars); r != nil && r.name != "" {
+			requests = append(requests, r)
+		}
+		block = nil
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.HasPrefix(line, "###") {
+			flush()
+			title = strings.TrimSpace(strings.TrimLeft(line, "#"))
+			continue
+		}
+		block = append(block, line)
+	}
+	flush()
+	return requests, fileVars
+}
+
+// parseHTTPRequest parses the lines between two `###` separators. Comments and file variables come before the
+// request line, which is followed by the headers and, after an empty line, the body.
+//
+//nolint:gocognit
+func parseHTTPRequest(lines []string, title string, fileVars map[string]string) *httpRequest {
+	r := &httpRequest{description: title, headers: make(map[string]string)}
+	var comments []string
+	i := 0
+	for ; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		switch {
+		case line == "":
+			continue
+		case httpMetadata.MatchString(line):
+			m := httpMetadata.FindStringSubmatch(line)
+			switch m[1] {
+			case "name":
+				r.name = strings.TrimSpace(m[2])
+			case "no-redirect":
+				r.noRedirect = true
+			}
+			continue
+		case strings.HasPrefix(line, "#") || strings.HasPrefix(line, "//"):
+			comments = append(comments, strings.TrimSpace(strings.TrimLeft(line, "#/")))
+			continue
+		case httpFileVariable.MatchString(line):
+			m := httpFileVariable.FindStringSubmatch(line)
+			fileVars[m[1]] = strings.TrimSpace(m[2])
+			continue
+		}
+		break
+	}
+	if i == len(lines) {
+		return nil
+	}
+
+	// e.g. "POST https://example.com/users HTTP/1.1", or just the URL of a GET request
+	fields := strings.Fields(lines[i])
+	if len(fields) > 1 && strings.HasPrefix(strings.ToUpper(fields[len(fields)-1]), "HTTP/") {
+		fields = fields[:len(fields)-1]
+	}
+	r.method = http.MethodGet
+	if len(fields) > 1 {
+		r.method, fields = strings.ToUpper(fields[0]), fields[1:]
+	}
+	r.url = strings.Join(fields, " ")
+	for i++; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if !strings.HasPrefix(line, "?") && !strings.HasPrefix(line, "&") {
+			break
+		}
+		r.url += line
+	}
+
+	for ; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if line == "" {
+			i++
+			break
+		}
+		if strings.HasPrefix(line, "#") || strings.HasPrefix(line, "//") {
+			continue
+		}
+		if key, value, found := strings.Cut(line, ":"); found {
+			r.headers[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	if i < len(lines) {
+		r.body = strings.TrimSpace(strings.Join(lines[i:], "\n"))
+	}
+
+	if r.description == "" {
+		r.description = strings.Join(comments, "\n")
+	}
+	if r.description == "" {
+		r.description = fmt.Sprintf("%s %s", r.method, r.url)
+	}
+	return r
+}
+
+// httpRequestSpec returns the request spec of a `.http` request, with its variables converted.
+func httpRequestSpec(
+	r *httpRequest, vars *httpVariables, wsPath, dir string,
+) (*executable.RequestExecutableType, *httpRefs, error) {
+	if !slices.Contains(httpMethods, r.method) {
+		return nil, nil, fmt.Errorf("request %s: unsupported method %s", r.name, r.method)
+	}
+	refs := &httpRefs{params: make(map[string]string)}
+	convert := func(s string) string { return vars.convert(r.name, s, refs, nil) }
+
+	spec := &executable.RequestExecutableType{
+		Method: executable.RequestExecutableTypeMethod(r.method),
+		URL:    convert(r.url),
+	}
+	if len(r.headers) > 0 {
+		spec.Headers = make(executable.RequestExecutableTypeHeaders, len(r.headers))
+		for _, key := range sortedKeys(r.headers) {
+			spec.Headers[key] = convert(r.headers[key])
+		}
+	}
+	if r.noRedirect {
+		followRedirects := false
+		spec.FollowRedirects = &followRedirects
+	}
+
+	body := r.body
+	switch {
+	case body == "":
+	case httpBodyFile.MatchString(body):
+		file := httpBodyFile.FindStringSubmatch(body)[1]
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(dir, file)
+		}
+		spec.BodyFile = shortenWsPath(wsPath, file)
+	case strings.Contains(strings.ToLower(headerValue(r.headers, "Content-Type")), "x-www-form-urlencoded"):
+		form, err := url.ParseQuery(strings.ReplaceAll(body, "\n", ""))
+		if err != nil {
+			return nil, nil, fmt.Errorf("request %s: unable to parse form body: %w", r.name, err)
+		}
+		spec.Form = make(executable.RequestExecutableTypeForm, len(form))
+		for _, key := range sortedKeys(form) {
+			spec.Form[key] = convert(form.Get(key))
+		}
+	case strings.HasPrefix(body, "{") || strings.HasPrefix(body, "["):
+		spec.Body = convert(body)
+	default:
+		// Bodies that aren't JSON (e.g. XML or text) would be evaluated as an expression, so they're sent as-is.
+		spec.RawBody = convert(body)
+	}
+
+	for _, key := range sortedKeys(refs.params) {
+		spec.Params = append(spec.Params, executable.Parameter{EnvKey: key, Text: refs.params[key]})
+	}
+	return spec, refs, nil
+}
+
+// convert replaces the `{{variable}}` references in s with environment variable references. File variables with a
+// static value are referenced as params, and file variables whose values reference other variables are inlined.
+// References to the responses of other requests are referenced by the key that the value is captured to.
+func (v *httpVariables) convert(request, s string, refs *httpRefs, seen []string) string {
+	return httpVariable.ReplaceAllStringFunc(s, func(match string) string {
+		name := httpVariable.FindStringSubmatch(match)[1]
+		if value, found := v.file[name]; found {
+			if !httpVariable.MatchString(value) {
+				refs.params[name] = value
+				return "${" + name + "}"
+			}
+			if slices.Contains(seen, name) {
+				return match
+			}
+			return v.convert(request, value, refs, append(seen, name))
+		}
+		if fields := strings.Fields(name); len(fields) == 2 && (fields[0] == "$processEnv" || fields[0] == "$dotenv") {
+			return "${" + strings.TrimPrefix(fields[1], "%") + "}"
+		}
+		if key, found := v.responseRef(request, name, refs); found {
+			return "${" + key + "}"
+		}
+		if strings.HasPrefix(name, "$") {
+			// Other system variables (e.g. `$guid`) aren't supported and are sent as-is.
+			return match
+		}
+		return "${" + name + "}"
+	})
+}
+
+// responseRef records a reference to a value of another request's response (e.g. `login.response.body.$.token` or
+// `login.response.headers.Location`) and returns the key that the value is captured to.
+func (v *httpVariables) responseRef(request, name string, refs *httpRefs) (string, bool) {
+	m := httpResponseRef.FindStringSubmatch(name)
+	if m == nil || m[1] == request {
+		return "", false
+	}
+	if _, found := v.requests[m[1]]; !found {
+		return "", false
+	}
+	var expr string
+	key := m[1] + ".response." + m[2]
+	switch {
+	case m[2] == "headers" && m[3] != "":
+		expr = fmt.Sprintf("headers[%q][0]", http.CanonicalHeaderKey(m[3]))
+		key += "." + m[3]
+	case m[2] == "body" && (m[3] == "" || m[3] == "*"):
+		expr = "body"
+	case m[2] == "body" && strings.HasPrefix(m[3], "$"):
+		path, ok := jsonPathExpr(m[3])
+		if !ok {
+			return "", false
+		}
+		expr = "fromJSON(body)" + path
+		if p := strings.TrimPrefix(strings.TrimPrefix(m[3], "$"), "."); p != "" {
+			key += "." + p
+		}
+	default:
+		return "", false
+	}
+
+	if v.captures[m[1]] == nil {
+		v.captures[m[1]] = make(map[string]string)
+	}
+	v.captures[m[1]][key] = expr
+	if !slices.Contains(refs.deps, m[1]) {
+		refs.deps = append(refs.deps, m[1])
+	}
+	return key, true
+}
+
+// jsonPathExpr converts a JSONPath with only child and index selectors (e.g. `$.items[0].id`) to the member accesses
+// of an expression.
+func jsonPathExpr(path string) (string, bool) {
+	path = strings.TrimPrefix(path, "$")
+	var expr strings.Builder
+	for path != "" {
+		switch path[0] {
+		case '.':
+			end := strings.IndexAny(path[1:], ".[")
+			if end < 0 {
+				end = len(path) - 1
+			}
+			field := path[1 : end+1]
+			switch {
+			case field == "":
+				return "", false
+			case httpIdentifier.MatchString(field):
+				expr.WriteString("." + field)
+			default:
+				expr.WriteString(fmt.Sprintf("[%q]", field))
+			}
+			path = path[end+1:]
+		case '[':
+			end := strings.Index(path, "]")
+			if end < 0 {
+				return "", false
+			}
+			selector := path[1:end]
+			if _, err := strconv.Atoi(selector); err == nil {
+				expr.WriteString("[" + selector + "]")
+			} else if unquoted := strings.Trim(selector, `'"`); len(unquoted) == len(selector)-2 {
+				expr.WriteString(fmt.Sprintf("[%q]", unquoted))
+			} else {
+				return "", false
+			}
+			path = path[end+1:]
+		default:
+			return "", false
+		}
+	}
+	return expr.String(), true
+}
+
+// checkHTTPChain returns an error when requests use each other's responses in a cycle.
+func checkHTTPChain(requests []*httpRequest, deps map[string][]string) error {
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := make(map[string]int, len(requests))
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch state[name] {
+		case visiting:
+			return fmt.Errorf("requests reference each other's responses: %s", strings.Join(append(path, name), " -> "))
+		case done:
+			return nil
+		}
+		state[name] = visiting
+		for _, dep := range deps[name] {
+			if err := visit(dep, append(path, name)); err != nil {
+				return err
+			}
+		}
+		state[name] = done
+		return nil
+	}
+	for _, r := range requests {
+		if err := visit(r.name, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// directHTTPDeps returns the dependencies of a request that aren't already sent by another of its dependencies.
+func directHTTPDeps(name string, deps map[string][]string) []string {
+	var reaches func(from, to string) bool
+	reaches = func(from, to string) bool {
+		for _, dep := range deps[from] {
+			if dep == to || reaches(dep, to) {
+				return true
+			}
+		}
+		return false
+	}
+	direct := make([]string, 0, len(deps[name]))
+	for _, dep := range deps[name] {
+		covered := false
+		for _, other := range deps[name] {
+			if other != dep && reaches(other, dep) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			direct = append(direct, dep)
+		}
+	}
+	return direct
+}
+
+func headerValue(headers map[string]string, name string) string {
+	for key, value := range headers {
+		if strings.EqualFold(key, name) {
+			return value
+		}
+	}
+	return ""
+}
+
+// isHTTPFile reports whether fn is a REST client file.
+func isHTTPFile(fn string) bool {
+	switch strings.ToLower(filepath.Ext(fn)) {
+	case ".http", ".rest":
+		return true
+	}
+	return false
+}
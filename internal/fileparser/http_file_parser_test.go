@@ -0,0 +1,125 @@
+package fileparser_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/flowexec/flow/v2/internal/fileparser"
+	"github.com/flowexec/flow/v2/types/common"
+	"github.com/flowexec/flow/v2/types/executable"
+)
+
+var _ = Describe("ExecutablesFromHTTPFile", func() {
+	var (
+		execs executable.ExecutableList
+		wsDir string
+	)
+
+	BeforeEach(func() {
+		var err error
+		wsDir, err = filepath.Abs("testdata")
+		Expect(err).NotTo(HaveOccurred())
+		execs, err = fileparser.ExecutablesFromHTTPFile(wsDir, filepath.Join(wsDir, "http", "api.http"))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should create a request executable for each named request", func() {
+		names := make([]string, 0, len(execs))
+		for _, e := range execs {
+			Expect(e.Tags).To(ConsistOf("generated", "http"))
+			names = append(names, e.Name)
+		}
+		Expect(names).To(Equal([]string{
+			"login", "list-users", "createUser", "createUser-send", "getUser", "getUser-send", "search",
+			"note", "note-send", "create-order",
+		}))
+	})
+
+	It("should convert variables, headers and the body", func() {
+		login := findExecutable(execs, executable.VerbRequest, "", "login")
+		Expect(login.Description).To(Equal("Log in"))
+		Expect(login.Request.Method).To(Equal(executable.RequestExecutableTypeMethodPOST))
+		Expect(login.Request.URL).To(Equal("${baseUrl}/login"))
+		Expect(login.Request.Headers).To(HaveKeyWithValue("Content-Type", "application/json"))
+		Expect(login.Request.Body).To(ContainSubstring(`"username": "${username}"`))
+		Expect(login.Request.Body).To(ContainSubstring(`"password": "${API_PASSWORD}"`))
+		Expect(login.Request.Params).To(ConsistOf(
+			executable.Parameter{EnvKey: "baseUrl", Text: "https://api.example.com"},
+		))
+
+		listUsers := findExecutable(execs, executable.VerbRequest, "", "list-users")
+		Expect(listUsers.Description).To(Equal("List the users"))
+		Expect(listUsers.Request.Method).To(Equal(executable.RequestExecutableTypeMethodGET))
+		Expect(listUsers.Request.URL).To(Equal("${baseUrl}/${apiVersion}/users?page=1&limit=10"))
+		Expect(listUsers.Request.Params).To(ConsistOf(
+			executable.Parameter{EnvKey: "apiVersion", Text: "v2"},
+			executable.Parameter{EnvKey: "baseUrl", Text: "https://api.example.com"},
+		))
+
+		search := findExecutable(execs, executable.VerbRequest, "", "search")
+		Expect(search.Request.Form).To(Equal(executable.RequestExecutableTypeForm{"q": "${query}", "sort": "name"}))
+		Expect(search.Request.Body).To(BeEmpty())
+
+		note := findExecutable(execs, executable.VerbRequest, "", "note-send")
+		Expect(note.Request.Body).To(BeEmpty())
+		Expect(note.Request.RawBody).To(Equal(`Hello "flow"`))
+
+		order := findExecutable(execs, executable.VerbRequest, "", "create-order")
+		Expect(order.Request.RawBody).To(Equal("<order><item>${item}</item></order>"))
+		Expect(order.Request.BodyFile).To(BeEmpty())
+	})
+
+	It("should read body files relative to the http file", func() {
+		Expect(findExecutable(execs, executable.VerbRequest, "", "createUser-send").Request.BodyFile).
+			To(Equal("//http/user.json"))
+	})
+
+	It("should capture the response values that other requests use", func() {
+		login := findExecutable(execs, executable.VerbRequest, "", "login")
+		Expect(login.Request.Capture).To(Equal(executable.RequestExecutableTypeCapture{
+			"login.response.body.token": "fromJSON(body).token",
+		}))
+		createUser := findExecutable(execs, executable.VerbRequest, "", "createUser-send")
+		Expect(createUser.Request.Capture).To(Equal(executable.RequestExecutableTypeCapture{
+			"createUser.response.headers.Location":         `headers["Location"][0]`,
+			"createUser.response.body.items[0]['note-id']": `fromJSON(body).items[0]["note-id"]`,
+		}))
+
+		getUser := findExecutable(execs, executable.VerbRequest, "", "getUser-send")
+		Expect(getUser.Request.URL).To(Equal("${createUser.response.headers.Location}"))
+		Expect(getUser.Request.Headers).To(HaveKeyWithValue("Authorization", "Bearer ${login.response.body.token}"))
+		Expect(getUser.Request.Headers).To(HaveKeyWithValue("X-Request-Id", "{{$guid}}"))
+		Expect(getUser.Request.FollowRedirects).To(HaveValue(BeFalse()))
+	})
+
+	It("should send the requests whose responses are used first", func() {
+		createUser := findExecutable(execs, executable.VerbRequest, "", "createUser")
+		Expect(createUser.Request).To(BeNil())
+		Expect(createUser.Serial.Execs).To(Equal(executable.SerialRefConfigList{
+			{Ref: "request login"},
+			{Ref: "request createUser-send"},
+		}))
+		internal := executable.ExecutableVisibility(common.VisibilityInternal)
+		Expect(findExecutable(execs, executable.VerbRequest, "", "createUser-send").Visibility).To(HaveValue(Equal(internal)))
+
+		// login is already sent by createUser
+		getUserFlow := findExecutable(execs, executable.VerbRequest, "", "getUser")
+		Expect(getUserFlow.Serial.Execs).To(Equal(executable.SerialRefConfigList{
+			{Ref: "request createUser"},
+			{Ref: "request getUser-send"},
+		}))
+	})
+
+	It("should fail when requests reference each other's responses", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "cycle.http")
+		Expect(os.WriteFile(path, []byte(
+			"# @name a\nGET https://example.com/{{b.response.body.*}}\n\n###\n"+
+				"# @name b\nGET https://example.com/{{a.response.body.*}}\n",
+		), 0600)).To(Succeed())
+		_, err := fileparser.ExecutablesFromHTTPFile("", path)
+		Expect(err).To(MatchError("requests reference each other's responses: a -> b -> a"))
+	})
+})
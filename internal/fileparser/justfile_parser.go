@@ -0,0 +1,422 @@
+package fileparser
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/flowexec/flow/v2/types/common"
+	"github.com/flowexec/flow/v2/types/executable"
+)
+
+type justRecipe struct {
+	name        string
+	description string
+	private     bool
+	groups      []string
+	aliases     []string
+	params      []justParam
+	deps        []justDep
+	postDeps    []justDep
+}
+
+type justParam struct {
+	name       string
+	defaultVal string
+	hasDefault bool
+	variadic   bool
+}
+
+type justDep struct {
+	recipe string
+	args   []string
+}
+
+var (
+	justTags = []string{generatedTag, "just"}
+
+	// e.g. "alias b := build"
+	justAliasLine = regexp.MustCompile(`^alias\s+([a-zA-Z0-9_-]+)\s*:=\s*([a-zA-Z0-9_-]+)\s*$`)
+	// e.g. "group('ci')", "group: 'ci'", "doc('Build the app')"
+	justAttrValue    = regexp.MustCompile(`^([a-z-]+)\s*(?:\(\s*(.*?)\s*\)|:\s*(.*?))?$`)
+	justRecipeName   = regexp.MustCompile(`^@?([a-zA-Z_][a-zA-Z0-9_-]*)$`)
+	justStatementKws = []string{"set", "export", "import", "mod", "unexport"}
+)
+
+// ExecutablesFromJustfile parses a justfile and returns an Executable for each of its recipes.
+// Recipes are run with `just <recipe>`; recipes with dependencies are run as serial executables that
+// reference the executables of their dependencies.
+func ExecutablesFromJustfile(wsPath, path string) (executable.ExecutableList, error) {
+	recipes, err := parseJustfile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := executable.Directory(shortenWsPath(wsPath, filepath.Dir(path)))
+	refs := make(map[string]executable.Ref, len(recipes))
+	for _, r := range recipes {
+		verb := InferVerb(r.name)
+		refs[r.name] = executable.NewRef(NormalizeName(r.name, verb.String()), verb)
+	}
+
+	execs := make(executable.ExecutableList, 0, len(recipes))
+	for _, r := range recipes {
+		e, err := justRecipeExecutable(r, dir, refs)
+		if err != nil {
+			return nil, err
+		}
+		execs = append(execs, e)
+	}
+	return execs, nil
+}
+
+func justRecipeExecutable(
+	r *justRecipe, dir executable.Directory, refs map[string]executable.Ref,
+) (*executable.Executable, error) {
+	verb := InferVerb(r.name)
+	e := &executable.Executable{
+		Verb:        verb,
+		Name:        NormalizeName(r.name, verb.String()),
+		Aliases:     r.aliases,
+		Description: r.description,
+		Tags:        append(append([]string{}, justTags...), r.groups...),
+	}
+	if r.private {
+		v := executable.ExecutableVisibility(common.VisibilityInternal)
+		e.Visibility = &v
+	}
+
+	args := make(executable.ArgumentList, 0, len(r.params))
+	cmdArgs := make([]string, 0, len(r.params))
+	for i, p := range r.params {
+		envKey := importArgEnvKey(p.name, "JUST_")
+		pos := i + 1
+		args = append(args, executable.Argument{
+			EnvKey:   envKey,
+			Pos:      &pos,
+			Default:  p.defaultVal,
+			Required: !p.hasDefault && !p.variadic,
+		})
+		switch {
+		case p.variadic:
+			cmdArgs = append(cmdArgs, "$"+envKey)
+		case p.hasDefault:
+			// An empty value leaves the parameter's default to just.
+			cmdArgs = append(cmdArgs, fmt.Sprintf(`${%s:+"$%s"}`, envKey, envKey))
+		default:
+			cmdArgs = append(cmdArgs, fmt.Sprintf(`"$%s"`, envKey))
+		}
+	}
+
+	if len(r.deps) == 0 && len(r.postDeps) == 0 {
+		e.Exec = &executable.ExecExecutableType{
+			Dir:  dir,
+			Cmd:  strings.TrimSpace(fmt.Sprintf("just %s %s", r.name, strings.Join(cmdArgs, " "))),
+			Args: args,
+		}
+		cfg, err := ExtractExecConfig(r.description, "")
+		if err != nil {
+			return nil, err
+		}
+		if len(cfg.SimpleFields) > 0 || len(cfg.Params) > 0 || len(cfg.Args) > 0 {
+			e.Description = ""
+			if err := ApplyExecConfig(e, cfg); err != nil {
+				return nil, err
+			}
+			e.Exec.Args = append(args, e.Exec.Args...)
+		}
+		return e, nil
+	}
+
+	// Dependencies are run through their own executables, so just is told not to run them again.
+	execs := make(executable.SerialRefConfigList, 0, len(r.deps)+len(r.postDeps)+1)
+	for _, dep := range r.deps {
+		execs = append(execs, justDepRefConfig(dep, refs))
+	}
+	execs = append(execs, executable.SerialRefConfig{
+		Cmd: strings.TrimSpace(fmt.Sprintf("just --no-deps %s %s", r.name, strings.Join(cmdArgs, " "))),
+	})
+	for _, dep := range r.postDeps {
+		execs = append(execs, justDepRefConfig(dep, refs))
+	}
+	e.Serial = &executable.SerialExecutableType{Dir: dir, Args: args, Execs: execs}
+	return e, nil
+}
+
+func justDepRefConfig(dep justDep, refs map[string]executable.Ref) executable.SerialRefConfig {
+	ref, found := refs[dep.recipe]
+	if !found {
+		return executable.SerialRefConfig{
+			Cmd: strings.TrimSpace(fmt.Sprintf("just %s %s", dep.recipe, strings.Join(quoteAll(dep.args), " "))),
+		}
+	}
+	return executable.SerialRefConfig{Ref: ref, Args: dep.args}
+}
+
+func quoteAll(values []string) []string {
+	quoted := make([]string, 0, len(values))
+	for _, v := range values {
+		quoted = append(quoted, fmt.Sprintf("%q", v))
+	}
+	return quoted
+}
+
+//nolint:gocognit
+func parseJustfile(path string) ([]*justRecipe, error) {
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open justfile: %w", err)
+	}
+	defer file.Close()
+
+	var (
+		recipes     []*justRecipe
+		aliases     = make(map[string][]string)
+		lastComment string
+		pending     = &justRecipe{}
+	)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		trim := strings.TrimSpace(line)
+		switch {
+		case trim == "":
+			lastComment = ""
+			pending = &justRecipe{}
+			continue
+		case line[0] == ' ' || line[0] == '\t':
+			continue // recipe body
+		case strings.HasPrefix(trim, "#"):
+			if !strings.HasPrefix(trim, "#!") {
+				lastComment = appendComment(lastComment, strings.TrimSpace(strings.TrimPrefix(trim, "#")))
+			}
+			continue
+		case strings.HasPrefix(trim, "["):
+			parseJustAttributes(trim, pending)
+			continue
+		}
+
+		if m := justAliasLine.FindStringSubmatch(trim); m != nil {
+			aliases[m[2]] = append(aliases[m[2]], m[1])
+			lastComment = ""
+			continue
+		}
+
+		header, deps, ok := splitJustRecipeHeader(trim)
+		if !ok || isJustStatement(header) {
+			lastComment = ""
+			pending = &justRecipe{}
+			continue
+		}
+		fields := splitJustTokens(header)
+		m := justRecipeName.FindStringSubmatch(fields[0])
+		if m == nil {
+			continue
+		}
+
+		r := pending
+		r.name = m[1]
+		r.private = r.private || strings.HasPrefix(r.name, "_")
+		if r.description == "" {
+			r.description = lastComment
+		}
+		for _, f := range fields[1:] {
+			r.params = append(r.params, parseJustParam(f))
+		}
+		r.deps, r.postDeps = parseJustDeps(deps)
+		recipes = append(recipes, r)
+
+		lastComment = ""
+		pending = &justRecipe{}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read justfile: %w", err)
+	}
+
+	for _, r := range recipes {
+		r.aliases = aliases[r.name]
+	}
+	return recipes, nil
+}
+
+func isJustStatement(header string) bool {
+	first := strings.Fields(header)
+	if len(first) == 0 {
+		return true
+	}
+	for _, kw := range justStatementKws {
+		if first[0] == kw && len(first) > 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// splitJustRecipeHeader splits a recipe line at the first colon outside of quotes and parentheses. Lines where that
+// colon starts a `:=` assignment are not recipes.
+func splitJustRecipeHeader(line string) (header, deps string, ok bool) {
+	var quote rune
+	depth := 0
+	for i, c := range line {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'' || c == '`':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == ':' && depth == 0:
+			if strings.HasPrefix(line[i:], ":=") {
+				return "", "", false
+			}
+			return strings.TrimSpace(line[:i]), strings.TrimSpace(line[i+1:]), true
+		}
+	}
+	return "", "", false
+}
+
+// splitJustTokens splits s on whitespace outside of quotes and parentheses.
+func splitJustTokens(s string) []string {
+	var (
+		tokens  []string
+		current strings.Builder
+		quote   rune
+		depth   int
+	)
+	flush := func() {
+		if current.Len() > 0 {
+			tokens = append(tokens, current.String())
+			current.Reset()
+		}
+	}
+	for _, c := range s {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'' || c == '`':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case (c == ' ' || c == '\t') && depth == 0:
+			flush()
+			continue
+		}
+		current.WriteRune(c)
+	}
+	flush()
+	return tokens
+}
+
+func parseJustParam(field string) justParam {
+	p := justParam{}
+	if strings.HasPrefix(field, "+") || strings.HasPrefix(field, "*") {
+		p.variadic = true
+		field = field[1:]
+	}
+	field = strings.TrimPrefix(field, "$")
+	name, value, hasDefault := strings.Cut(field, "=")
+	p.name = name
+	if hasDefault {
+		p.hasDefault = true
+		p.defaultVal = unquoteJust(value)
+	}
+	return p
+}
+
+// unquoteJust returns the value of a string literal. Other expressions (variables, function calls, backticks) can
+// only be evaluated by just, so they're returned empty to leave the default to just.
+func unquoteJust(value string) string {
+	if len(value) >= 2 {
+		first, last := value[0], value[len(value)-1]
+		if (first == '"' || first == '\'') && first == last {
+			return value[1 : len(value)-1]
+		}
+	}
+	return ""
+}
+
+func parseJustDeps(s string) (deps, postDeps []justDep) {
+	target := &deps
+	for _, token := range splitJustTokens(s) {
+		if token == "&&" {
+			target = &postDeps
+			continue
+		}
+		if strings.HasPrefix(token, "(") && strings.HasSuffix(token, ")") {
+			parts := splitJustTokens(strings.TrimSpace(token[1 : len(token)-1]))
+			if len(parts) == 0 {
+				continue
+			}
+			dep := justDep{recipe: parts[0]}
+			for _, arg := range parts[1:] {
+				if v := unquoteJust(arg); v != "" {
+					arg = v
+				}
+				dep.args = append(dep.args, arg)
+			}
+			*target = append(*target, dep)
+			continue
+		}
+		*target = append(*target, justDep{recipe: token})
+	}
+	return deps, postDeps
+}
+
+// parseJustAttributes applies the recipe attributes on a line (e.g. `[private, group('ci')]`) to r.
+func parseJustAttributes(line string, r *justRecipe) {
+	inner := strings.TrimSuffix(strings.TrimPrefix(line, "["), "]")
+	for _, attr := range splitJustAttributes(inner) {
+		m := justAttrValue.FindStringSubmatch(strings.TrimSpace(attr))
+		if m == nil {
+			continue
+		}
+		value := m[2]
+		if value == "" {
+			value = m[3]
+		}
+		switch m[1] {
+		case "private":
+			r.private = true
+		case "group":
+			if g := unquoteJust(value); g != "" {
+				r.groups = append(r.groups, g)
+			}
+		case "doc":
+			r.description = unquoteJust(value)
+		}
+	}
+}
+
+// splitJustAttributes splits comma separated attributes, ignoring commas inside of quotes.
+func splitJustAttributes(s string) []string {
+	var (
+		attrs []string
+		quote rune
+		start int
+	)
+	for i, c := range s {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == ',':
+			attrs = append(attrs, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(attrs, s[start:])
+}
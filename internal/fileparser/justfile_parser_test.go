@@ -0,0 +1,71 @@
+package fileparser_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/flowexec/flow/v2/internal/fileparser"
+	"github.com/flowexec/flow/v2/types/common"
+	"github.com/flowexec/flow/v2/types/executable"
+)
+
+var _ = Describe("ExecutablesFromJustfile", func() {
+	const justfile = "testdata/justfile"
+
+	var execs executable.ExecutableList
+
+	BeforeEach(func() {
+		var err error
+		execs, err = fileparser.ExecutablesFromJustfile("", justfile)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should create an executable for each recipe", func() {
+		Expect(execs).To(HaveLen(6))
+	})
+
+	It("should map recipe parameters to args", func() {
+		build := findExecutable(execs, executable.VerbBuild, "", "")
+		Expect(build.Description).To(Equal("Build the application"))
+		Expect(build.Aliases).To(ConsistOf("b"))
+		Expect(build.Tags).To(ConsistOf("generated", "just", "dev"))
+		Expect(build.Exec.Cmd).To(Equal(`just build ${TARGET:+"$TARGET"} $FLAGS`))
+		Expect(build.Exec.Args).To(HaveLen(2))
+		Expect(build.Exec.Args[0].EnvKey).To(Equal("TARGET"))
+		Expect(build.Exec.Args[0].Default).To(Equal("debug"))
+		Expect(build.Exec.Args[0].Pos).To(HaveValue(Equal(1)))
+		Expect(build.Exec.Args[1].Required).To(BeFalse())
+	})
+
+	It("should map private recipes to internal executables", func() {
+		for _, e := range []*executable.Executable{
+			findExecutable(execs, executable.VerbInstall, "", "setup-env"),
+			findExecutable(execs, executable.VerbExec, "", "helper"),
+		} {
+			Expect(e.Visibility).To(HaveValue(Equal(executable.ExecutableVisibility(common.VisibilityInternal))))
+		}
+		Expect(findExecutable(execs, executable.VerbExec, "", "helper").Exec.Args[0].Required).To(BeTrue())
+	})
+
+	It("should map recipe dependencies to serial refs", func() {
+		test := findExecutable(execs, executable.VerbTest, "", "")
+		Expect(test.Serial).NotTo(BeNil())
+		Expect(test.Serial.Execs).To(HaveLen(2))
+		Expect(test.Serial.Execs[0].Ref).To(Equal(executable.Ref("build")))
+		Expect(test.Serial.Execs[1].Cmd).To(Equal("just --no-deps test"))
+
+		release := findExecutable(execs, executable.VerbRelease, "", "")
+		Expect(release.Description).To(Equal("Release a new version"))
+		Expect(release.Tags).To(ContainElement("ci"))
+		Expect(release.Serial.Execs).To(HaveLen(4))
+		Expect(release.Serial.Execs[0].Ref).To(Equal(executable.Ref("build")))
+		Expect(release.Serial.Execs[0].Args).To(Equal([]string{"release"}))
+		Expect(release.Serial.Execs[2].Cmd).To(Equal(`just --no-deps release "$CHANNEL"`))
+		Expect(release.Serial.Execs[3].Ref).To(Equal(executable.Ref("exec helper")))
+	})
+
+	It("should apply flow configuration comments", func() {
+		serve := findExecutable(execs, executable.VerbStart, "", "server")
+		Expect(serve.Exec.Cmd).To(Equal("just serve"))
+	})
+})
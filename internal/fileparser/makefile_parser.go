@@ -12,67 +12,71 @@ import (
 )
 
 type makeTarget struct {
-	name        string
-	description string
+	name          string
+	description   string
+	prerequisites []string
+}
+
+type makeVar struct {
+	name       string
+	defaultVal string
+}
+
+type makefile struct {
+	targets []*makeTarget
+	byName  map[string]*makeTarget
+	phony   map[string]bool
+	vars    []makeVar
+	// values are the values of the makefile's variables, used to expand variable references in prerequisites.
+	values map[string]string
 }
 
-// e.g. "target: dep1 dep2"
 var (
-	targetLine = regexp.MustCompile(`^([a-zA-Z0-9_.-]+)\s*:(.*)$`)
-	makeTags   = []string{generatedTag, "make"}
+	// e.g. "target: dep1 dep2", "target1 target2: dep1 ## help text"
+	targetLine = regexp.MustCompile(`^([a-zA-Z0-9_./-]+(?:\s+[a-zA-Z0-9_./-]+)*)\s*::?(.*)$`)
+	// e.g. "VAR ?= default", "override VAR ?= default"
+	overridableVarLine = regexp.MustCompile(`^(?:override\s+|export\s+)?([a-zA-Z_][a-zA-Z0-9_]*)\s*\?=\s*(.*)$`)
+	// e.g. "VAR := value", "VAR = value"
+	assignmentLine = regexp.MustCompile(`^(?:override\s+|export\s+)?([a-zA-Z_][a-zA-Z0-9_]*)\s*(?::{0,3}=)\s*(.*)$`)
+	varReference   = regexp.MustCompile(`\$[({]([a-zA-Z_][a-zA-Z0-9_]*)[)}]`)
+	includeLine    = regexp.MustCompile(`^(?:-|s)?include\s+(.+)$`)
+	makeTags       = []string{generatedTag, "make"}
 )
 
-// ExecutablesFromMakefile parses a Makefile and returns a list of Executables for each makeTarget
+// ExecutablesFromMakefile parses a Makefile and returns a list of Executables for each makeTarget. Targets of included
+// makefiles are imported as well, and overridable (`?=`) variables become args that are passed to make.
 func ExecutablesFromMakefile(wsPath, path string) (executable.ExecutableList, error) {
-	file, err := os.Open(filepath.Clean(path))
-	if err != nil {
-		return nil, fmt.Errorf("failed to open Makefile: %w", err)
+	mf := &makefile{
+		byName: make(map[string]*makeTarget),
+		phony:  make(map[string]bool),
+		values: make(map[string]string),
+	}
+	if err := mf.parse(path, map[string]bool{}); err != nil {
+		return nil, err
 	}
-	defer file.Close()
 
-	scanner := bufio.NewScanner(file)
-	targets := make(map[string]*makeTarget)
-	var lastComment string
+	execs := make(executable.ExecutableList, 0, len(mf.targets))
+	dir := executable.Directory(shortenWsPath(wsPath, filepath.Dir(path)))
 
-	for scanner.Scan() {
-		line := scanner.Text()
-		trim := strings.TrimSpace(line)
-		if trim == "" {
-			lastComment = ""
-			continue
-		}
-		if strings.HasPrefix(trim, "#") {
-			lastComment = appendComment(lastComment, strings.TrimSpace(strings.TrimPrefix(trim, "#")))
+	for _, t := range mf.targets {
+		// Targets that name files (e.g. bin/app) are built as prerequisites rather than run on their own.
+		if !mf.phony[t.name] && strings.ContainsAny(t.name, "./") {
 			continue
 		}
-		if m := targetLine.FindStringSubmatch(line); m != nil {
-			name := m[1]
-
-			// Skip special targets and pattern rules
-			// TODO: add support for these targets
-			if strings.HasPrefix(name, ".") || strings.Contains(name, "%") {
-				continue
-			}
-
-			targets[name] = &makeTarget{name: name, description: lastComment}
-			lastComment = ""
-		}
-	}
-
-	execs := make(executable.ExecutableList, 0, len(targets))
-	dir := executable.Directory(shortenWsPath(wsPath, filepath.Dir(path)))
 
-	for _, t := range targets {
 		verb := InferVerb(t.name)
 		execName := NormalizeName(t.name, verb.String())
+		args, cmdArgs := mf.args()
 		e := &executable.Executable{
 			Name:        execName,
 			Verb:        verb,
 			Description: t.description,
 			Tags:        makeTags,
 			Exec: &executable.ExecExecutableType{
-				Dir: dir,
-				Cmd: fmt.Sprintf("make %s", t.name),
+				Dir:           dir,
+				Cmd:           strings.TrimSpace(fmt.Sprintf("make %s %s", t.name, strings.Join(cmdArgs, " "))),
+				Args:          args,
+				Prerequisites: mf.prerequisiteChain(t),
 			},
 		}
 
@@ -86,6 +90,7 @@ func ExecutablesFromMakefile(wsPath, path string) (executable.ExecutableList, er
 			if err := ApplyExecConfig(e, cfg); err != nil {
 				return nil, err
 			}
+			e.Exec.Args = append(args, e.Exec.Args...)
 		}
 
 		execs = append(execs, e)
@@ -93,6 +98,205 @@ func ExecutablesFromMakefile(wsPath, path string) (executable.ExecutableList, er
 	return execs, nil
 }
 
+//nolint:gocognit
+func (mf *makefile) parse(path string, including map[string]bool) error {
+	if including[path] {
+		return nil
+	}
+	including[path] = true
+
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return fmt.Errorf("failed to open Makefile: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	var lastComment, continued string
+
+	for scanner.Scan() {
+		line := continued + scanner.Text()
+		if strings.HasSuffix(line, "\\") {
+			continued = strings.TrimSuffix(line, "\\") + " "
+			continue
+		}
+		continued = ""
+
+		trim := strings.TrimSpace(line)
+		switch {
+		case trim == "":
+			lastComment = ""
+			continue
+		case strings.HasPrefix(line, "\t"):
+			continue // recipe
+		case strings.HasPrefix(trim, "#"):
+			lastComment = appendComment(lastComment, strings.TrimSpace(strings.TrimLeft(trim, "#")))
+			continue
+		}
+
+		if m := includeLine.FindStringSubmatch(trim); m != nil {
+			lastComment = ""
+			if err := mf.include(filepath.Dir(path), m[1], strings.HasPrefix(trim, "include"), including); err != nil {
+				return err
+			}
+			continue
+		}
+		if m := overridableVarLine.FindStringSubmatch(trim); m != nil {
+			lastComment = ""
+			mf.addVar(m[1], strings.TrimSpace(stripMakeComment(m[2])))
+			continue
+		}
+		if m := assignmentLine.FindStringSubmatch(trim); m != nil {
+			lastComment = ""
+			mf.values[m[1]] = strings.TrimSpace(stripMakeComment(m[2]))
+			continue
+		}
+
+		m := targetLine.FindStringSubmatch(line)
+		if m == nil {
+			lastComment = ""
+			continue
+		}
+		rest, help, _ := strings.Cut(m[2], "##")
+		description := lastComment
+		if strings.TrimSpace(help) != "" {
+			description = strings.TrimSpace(help)
+		}
+		lastComment = ""
+		rest = stripMakeComment(rest)
+		// Target-specific variable assignments (e.g. `target: VAR = value`) aren't rules.
+		if strings.Contains(rest, "=") {
+			continue
+		}
+		prereqs := strings.Fields(strings.ReplaceAll(mf.expand(rest), "|", " "))
+
+		for _, name := range strings.Fields(m[1]) {
+			if name == ".PHONY" {
+				for _, p := range prereqs {
+					mf.phony[p] = true
+				}
+				continue
+			}
+			// Special targets and pattern rules can't be run on their own.
+			if strings.HasPrefix(name, ".") || strings.Contains(name, "%") {
+				continue
+			}
+			mf.addTarget(name, description, prereqs)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read Makefile: %w", err)
+	}
+	return nil
+}
+
+// include parses the makefiles of an include directive. Missing makefiles are only an error for `include`; `-include`
+// and `sinclude` ignore them like make does.
+func (mf *makefile) include(dir, files string, required bool, including map[string]bool) error {
+	for _, f := range strings.Fields(stripMakeComment(files)) {
+		// Makefiles named with variables or functions can only be resolved by make.
+		if strings.Contains(f, "$") {
+			continue
+		}
+		if !filepath.IsAbs(f) {
+			f = filepath.Join(dir, f)
+		}
+		matches, err := filepath.Glob(f)
+		if err != nil || len(matches) == 0 {
+			if required {
+				return fmt.Errorf("included makefile %s not found", f)
+			}
+			continue
+		}
+		for _, match := range matches {
+			if err := mf.parse(match, including); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (mf *makefile) addTarget(name, description string, prereqs []string) {
+	t, found := mf.byName[name]
+	if !found {
+		t = &makeTarget{name: name}
+		mf.byName[name] = t
+		mf.targets = append(mf.targets, t)
+	}
+	if t.description == "" {
+		t.description = description
+	}
+	t.prerequisites = append(t.prerequisites, prereqs...)
+}
+
+func (mf *makefile) addVar(name, defaultVal string) {
+	if _, set := mf.values[name]; !set {
+		mf.values[name] = defaultVal
+	}
+	for _, v := range mf.vars {
+		if v.name == name {
+			return
+		}
+	}
+	mf.vars = append(mf.vars, makeVar{name: name, defaultVal: defaultVal})
+}
+
+// args returns the args for the makefile's overridable variables, along with the make arguments that pass them. Empty
+// values leave the variable's default to make.
+func (mf *makefile) args() (executable.ArgumentList, []string) {
+	args := make(executable.ArgumentList, 0, len(mf.vars))
+	cmdArgs := make([]string, 0, len(mf.vars))
+	for _, v := range mf.vars {
+		envKey := importArgEnvKey(v.name, "MAKE_")
+		arg := executable.Argument{EnvKey: envKey, Flag: strings.ToLower(strings.ReplaceAll(v.name, "_", "-"))}
+		// Defaults that reference other variables or functions can only be expanded by make.
+		if !strings.Contains(v.defaultVal, "$") {
+			arg.Default = v.defaultVal
+		}
+		args = append(args, arg)
+		cmdArgs = append(cmdArgs, fmt.Sprintf(`${%s:+%s="$%s"}`, envKey, v.name, envKey))
+	}
+	return args, cmdArgs
+}
+
+// prerequisiteChain returns the targets that make runs before t, in the order that they run.
+func (mf *makefile) prerequisiteChain(t *makeTarget) []string {
+	var chain []string
+	visited := map[string]bool{t.name: true}
+	var visit func(*makeTarget)
+	visit = func(target *makeTarget) {
+		for _, p := range target.prerequisites {
+			dep, found := mf.byName[p]
+			if !found || visited[p] {
+				continue
+			}
+			visited[p] = true
+			visit(dep)
+			chain = append(chain, p)
+		}
+	}
+	visit(t)
+	return chain
+}
+
+// expand replaces references to the makefile's variables in s with their values.
+func (mf *makefile) expand(s string) string {
+	return varReference.ReplaceAllStringFunc(s, func(ref string) string {
+		if value, found := mf.values[varReference.FindStringSubmatch(ref)[1]]; found {
+			return value
+		}
+		return ref
+	})
+}
+
+func stripMakeComment(s string) string {
+	if i := strings.Index(s, "#"); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
+
 func appendComment(s string, comment string) string {
 	if s == "" {
 		return comment
@@ -8,6 +8,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/flowexec/flow/v2/internal/fileparser"
+	"github.com/flowexec/flow/v2/types/executable"
 )
 
 var _ = Describe("ExecutablesFromMakefile", func() {
@@ -47,3 +48,49 @@ var _ = Describe("ExecutablesFromMakefile", func() {
 		}
 	})
 })
+
+var _ = Describe("ExecutablesFromMakefile with includes and variables", func() {
+	const makefile = "testdata/make/Makefile"
+
+	var execs executable.ExecutableList
+
+	BeforeEach(func() {
+		var err error
+		execs, err = fileparser.ExecutablesFromMakefile("", makefile)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should import phony targets and targets of included makefiles", func() {
+		Expect(execs).To(HaveLen(5))
+		Expect(findExecutable(execs, executable.VerbGenerate, "", "").Description).To(Equal("Generate code"))
+		for _, e := range execs {
+			Expect(e.Name).NotTo(ContainSubstring("bin"))
+		}
+	})
+
+	It("should use help comments as descriptions", func() {
+		Expect(findExecutable(execs, executable.VerbBuild, "", "").Description).To(Equal("Build the application"))
+		Expect(findExecutable(execs, executable.VerbTest, "", "").Description).To(Equal("Run the tests"))
+		Expect(findExecutable(execs, executable.VerbLint, "", "").Description).To(Equal("Run the linters"))
+	})
+
+	It("should map overridable variables to args", func() {
+		build := findExecutable(execs, executable.VerbBuild, "", "")
+		Expect(build.Exec.Cmd).To(Equal(
+			`make build ${VERSION:+VERSION="$VERSION"} ${GOFLAGS:+GOFLAGS="$GOFLAGS"} ${OUTPUT:+OUTPUT="$OUTPUT"}`,
+		))
+		Expect(build.Exec.Args).To(HaveLen(3))
+		Expect(build.Exec.Args[0].Flag).To(Equal("version"))
+		Expect(build.Exec.Args[0].Default).To(Equal("0.1.0"))
+		Expect(build.Exec.Args[1].Default).To(BeEmpty())
+		Expect(build.Exec.Args[2].Default).To(Equal("bin"))
+	})
+
+	It("should record the prerequisite chain", func() {
+		Expect(findExecutable(execs, executable.VerbBuild, "", "").Exec.Prerequisites).
+			To(Equal([]string{"generate", "bin/app"}))
+		Expect(findExecutable(execs, executable.VerbTest, "", "").Exec.Prerequisites).To(
+			Equal([]string{"generate", "bin/app", "build", "lint", "clean"}),
+		)
+	})
+})
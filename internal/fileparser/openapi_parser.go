@@ -0,0 +1,433 @@
+package fileparser
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/flowexec/flow/v2/types/executable"
+)
+
+type openAPISpec struct {
+	Info struct {
+		Title string `yaml:"title"`
+	} `yaml:"info"`
+	Servers    []openAPIServer            `yaml:"servers"`
+	Paths      map[string]openAPIPathItem `yaml:"paths"`
+	Components struct {
+		Parameters    map[string]openAPIParameter   `yaml:"parameters"`
+		RequestBodies map[string]openAPIRequestBody `yaml:"requestBodies"`
+	} `yaml:"components"`
+}
+
+type openAPIServer struct {
+	URL       string `yaml:"url"`
+	Variables map[string]struct {
+		Default string `yaml:"default"`
+	} `yaml:"variables"`
+}
+
+type openAPIPathItem struct {
+	Parameters []openAPIParameter `yaml:"parameters"`
+	Get        *openAPIOperation  `yaml:"get"`
+	Put        *openAPIOperation  `yaml:"put"`
+	Post       *openAPIOperation  `yaml:"post"`
+	Delete     *openAPIOperation  `yaml:"delete"`
+	Options    *openAPIOperation  `yaml:"options"`
+	Head       *openAPIOperation  `yaml:"head"`
+	Patch      *openAPIOperation  `yaml:"patch"`
+}
+
+type openAPIOperation struct {
+	OperationID string              `yaml:"operationId"`
+	Summary     string              `yaml:"summary"`
+	Description string              `yaml:"description"`
+	Tags        []string            `yaml:"tags"`
+	Parameters  []openAPIParameter  `yaml:"parameters"`
+	RequestBody *openAPIRequestBody `yaml:"requestBody"`
+}
+
+type openAPIParameter struct {
+	Ref         string         `yaml:"$ref"`
+	Name        string         `yaml:"name"`
+	In          string         `yaml:"in"`
+	Required    bool           `yaml:"required"`
+	Description string         `yaml:"description"`
+	Schema      *openAPISchema `yaml:"schema"`
+}
+
+type openAPISchema struct {
+	Type    string `yaml:"type"`
+	Default any    `yaml:"default"`
+}
+
+type openAPIRequestBody struct {
+	Ref      string                      `yaml:"$ref"`
+	Required bool                        `yaml:"required"`
+	Content  map[string]openAPIMediaType `yaml:"content"`
+}
+
+type openAPIMediaType struct {
+	Example any `yaml:"example"`
+}
+
+var (
+	openAPITags = []string{generatedTag, "openapi"}
+	// e.g. "/users/{userId}"
+	openAPIPathParam = regexp.MustCompile(`\{([^{}]+)\}`)
+	repeatedDashes   = regexp.MustCompile(`-+`)
+
+	// openAPIVerbs are the verbs of the executables that are generated for each HTTP method.
+	openAPIVerbs = map[string]executable.Verb{
+		http.MethodGet:     executable.VerbGet,
+		http.MethodPost:    executable.VerbCreate,
+		http.MethodPut:     executable.VerbUpdate,
+		http.MethodPatch:   executable.VerbUpdate,
+		http.MethodDelete:  executable.VerbDelete,
+		http.MethodHead:    executable.VerbRequest,
+		http.MethodOptions: executable.VerbRequest,
+	}
+)
+
+// ExecutablesFromOpenAPI parses an OpenAPI 3 spec and returns a request Executable for each of its operations. The
+// server URL is read from a param named after the spec's title (e.g. `USERS_API_URL`), which defaults to the spec's
+// first server, so it can be set per workspace or profile.
+func ExecutablesFromOpenAPI(wsPath, path string) (executable.ExecutableList, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open OpenAPI spec: %w", err)
+	}
+	// JSON is valid YAML, so both formats are decoded the same way.
+	var spec openAPISpec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("failed to decode OpenAPI spec: %w", err)
+	}
+
+	serverParam := executable.Parameter{EnvKey: openAPIServerEnvKey(spec.Info.Title)}
+	if len(spec.Servers) > 0 {
+		serverParam.Text = spec.Servers[0].defaultURL()
+	}
+
+	execs := make(executable.ExecutableList, 0)
+	for _, p := range sortedKeys(spec.Paths) {
+		item := spec.Paths[p]
+		for _, op := range item.operations() {
+			e, err := spec.operationExecutable(p, op.method, op.operation, item.Parameters, serverParam)
+			if err != nil {
+				return nil, err
+			}
+			execs = append(execs, e)
+		}
+	}
+	return execs, nil
+}
+
+type openAPIMethodOperation struct {
+	method    string
+	operation *openAPIOperation
+}
+
+func (item openAPIPathItem) operations() []openAPIMethodOperation {
+	var ops []openAPIMethodOperation
+	for _, op := range []openAPIMethodOperation{
+		{http.MethodGet, item.Get},
+		{http.MethodPost, item.Post},
+		{http.MethodPut, item.Put},
+		{http.MethodPatch, item.Patch},
+		{http.MethodDelete, item.Delete},
+		{http.MethodHead, item.Head},
+		{http.MethodOptions, item.Options},
+	} {
+		if op.operation != nil {
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
+
+//nolint:gocognit
+func (s *openAPISpec) operationExecutable(
+	path, method string,
+	op *openAPIOperation,
+	pathParams []openAPIParameter,
+	serverParam executable.Parameter,
+) (*executable.Executable, error) {
+	verb := openAPIVerbs[method]
+	name := op.OperationID
+	if name == "" {
+		name = strings.ToLower(method) + "-" + path
+	}
+	name = NormalizeName(strings.TrimPrefix(kebabCase(name), verb.String()+"-"), "")
+
+	req := &executable.RequestExecutableType{
+		Method: executable.RequestExecutableTypeMethod(method),
+		Params: executable.ParameterList{serverParam},
+	}
+	var args executable.ArgumentList
+	envKeys := make(map[string]string)
+
+	params, err := s.operationParams(pathParams, op.Parameters)
+	if err != nil {
+		return nil, fmt.Errorf("operation %s: %w", name, err)
+	}
+	for _, param := range params {
+		envKey := openAPIEnvKey(param.Name)
+		arg := executable.Argument{EnvKey: envKey, Required: param.Required}
+		if param.Schema != nil {
+			if param.Schema.Default != nil {
+				arg.Default = fmt.Sprint(param.Schema.Default)
+			}
+			if param.Required {
+				arg.Type = openAPIArgType(param.Schema.Type)
+			}
+		}
+		switch param.In {
+		case "path":
+			envKeys[param.Name] = envKey
+			continue
+		case "query":
+			if req.Query == nil {
+				req.Query = make(executable.RequestExecutableTypeQuery)
+			}
+			req.Query[param.Name] = "$" + envKey
+		case "header":
+			if req.Headers == nil {
+				req.Headers = make(executable.RequestExecutableTypeHeaders)
+			}
+			req.Headers[param.Name] = "$" + envKey
+		default:
+			// Cookie parameters aren't supported.
+			continue
+		}
+		arg.Flag = param.Name
+		args = append(args, arg)
+	}
+
+	// Path parameters are positional args, in the order that they appear in the path.
+	pos := 0
+	req.URL = "${" + serverParam.EnvKey + "}" + openAPIPathParam.ReplaceAllStringFunc(path, func(match string) string {
+		paramName := match[1 : len(match)-1]
+		envKey, found := envKeys[paramName]
+		if !found {
+			envKey = openAPIEnvKey(paramName)
+		}
+		pos++
+		argPos := pos
+		arg := executable.Argument{Pos: &argPos, EnvKey: envKey, Required: true}
+		for _, param := range params {
+			if param.Name == paramName && param.In == "path" && param.Schema != nil {
+				arg.Type = openAPIArgType(param.Schema.Type)
+			}
+		}
+		args = append(args, arg)
+		return "${" + envKey + "}"
+	})
+
+	if op.RequestBody != nil {
+		body, err := s.requestBody(op.RequestBody)
+		if err != nil {
+			return nil, fmt.Errorf("operation %s: %w", name, err)
+		}
+		bodyArg := executable.Argument{Flag: "body", EnvKey: "BODY"}
+		if contentType := openAPIContentType(body.Content); contentType != "" {
+			media := body.Content[contentType]
+			if media.Example != nil {
+				example, err := openAPIExample(media.Example)
+				if err != nil {
+					return nil, fmt.Errorf("operation %s: %w", name, err)
+				}
+				bodyArg.Default = example
+			}
+			if req.Headers == nil {
+				req.Headers = make(executable.RequestExecutableTypeHeaders)
+			}
+			req.Headers["Content-Type"] = contentType
+		}
+		req.Body = "$BODY"
+		req.BodyFile = "$BODY_FILE"
+		args = append(args, bodyArg, executable.Argument{Flag: "body-file", EnvKey: "BODY_FILE"})
+	}
+	req.Args = args
+
+	description := strings.TrimSpace(op.Summary)
+	if d := strings.TrimSpace(op.Description); d != "" && d != description {
+		description = strings.TrimSpace(description + "\n\n" + d)
+	}
+	if description == "" {
+		description = fmt.Sprintf("%s %s", method, path)
+	}
+	tags := append(append([]string{}, openAPITags...), op.Tags...)
+	return &executable.Executable{
+		Verb:        verb,
+		Name:        name,
+		Description: description,
+		Tags:        tags,
+		Request:     req,
+	}, nil
+}
+
+// operationParams returns the parameters of an operation, including the parameters of its path that it doesn't
+// override, with their refs resolved.
+func (s *openAPISpec) operationParams(pathParams, opParams []openAPIParameter) ([]openAPIParameter, error) {
+	var params []openAPIParameter
+	seen := make(map[string]bool)
+	for _, list := range [][]openAPIParameter{opParams, pathParams} {
+		for _, param := range list {
+			resolved, err := s.resolveParam(param)
+			if err != nil {
+				return nil, err
+			}
+			key := resolved.In + ":" + resolved.Name
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			params = append(params, resolved)
+		}
+	}
+	return params, nil
+}
+
+func (s *openAPISpec) resolveParam(param openAPIParameter) (openAPIParameter, error) {
+	if param.Ref == "" {
+		return param, nil
+	}
+	name, found := strings.CutPrefix(param.Ref, "#/components/parameters/")
+	resolved, ok := s.Components.Parameters[name]
+	if !found || !ok {
+		return param, fmt.Errorf("unable to resolve parameter %s", param.Ref)
+	}
+	return resolved, nil
+}
+
+func (s *openAPISpec) requestBody(body *openAPIRequestBody) (*openAPIRequestBody, error) {
+	if body.Ref == "" {
+		return body, nil
+	}
+	name, found := strings.CutPrefix(body.Ref, "#/components/requestBodies/")
+	resolved, ok := s.Components.RequestBodies[name]
+	if !found || !ok {
+		return nil, fmt.Errorf("unable to resolve request body %s", body.Ref)
+	}
+	return &resolved, nil
+}
+
+// defaultURL returns the server's URL with its variables set to their defaults.
+func (s openAPIServer) defaultURL() string {
+	return openAPIPathParam.ReplaceAllStringFunc(s.URL, func(match string) string {
+		if v, found := s.Variables[match[1:len(match)-1]]; found {
+			return v.Default
+		}
+		return match
+	})
+}
+
+// openAPIServerEnvKey returns the env key of the param that sets the server URL of a spec, e.g. `USERS_API_URL`
+// for the "Users API".
+func openAPIServerEnvKey(title string) string {
+	key := strings.Trim(invalidEnvChars.ReplaceAllString(strings.ToUpper(title), "_"), "_")
+	if key == "" {
+		key = "API"
+	}
+	return key + "_URL"
+}
+
+// openAPIExample returns an example value as the JSON that's sent as a body, or as-is when it's a string.
+func openAPIExample(example any) (string, error) {
+	if str, ok := example.(string); ok {
+		return str, nil
+	}
+	data, err := json.Marshal(jsonCompatible(example))
+	if err != nil {
+		return "", fmt.Errorf("unable to encode example: %w", err)
+	}
+	return string(data), nil
+}
+
+// jsonCompatible converts the maps that YAML decodes into ones that can be encoded as JSON.
+func jsonCompatible(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			v[key] = jsonCompatible(value)
+		}
+		return v
+	case map[any]any:
+		m := make(map[string]any, len(v))
+		for key, value := range v {
+			m[fmt.Sprint(key)] = jsonCompatible(value)
+		}
+		return m
+	case []any:
+		for i, value := range v {
+			v[i] = jsonCompatible(value)
+		}
+		return v
+	}
+	return v
+}
+
+// openAPIContentType returns the media type that a request body is sent as, preferring JSON.
+func openAPIContentType(content map[string]openAPIMediaType) string {
+	if _, found := content["application/json"]; found {
+		return "application/json"
+	}
+	if types := sortedKeys(content); len(types) > 0 {
+		return types[0]
+	}
+	return ""
+}
+
+// openAPIEnvKey returns the env key of the arg for a parameter, e.g. `USER_ID` for `userId`.
+func openAPIEnvKey(name string) string {
+	return importArgEnvKey(strings.ReplaceAll(kebabCase(name), "-", "_"), "PARAM_")
+}
+
+func openAPIArgType(schemaType string) executable.ArgumentType {
+	switch schemaType {
+	case "integer":
+		return executable.ArgumentTypeInt
+	case "number":
+		return executable.ArgumentTypeFloat
+	case "boolean":
+		return executable.ArgumentTypeBool
+	}
+	return executable.ArgumentTypeString
+}
+
+// kebabCase converts an operation ID (e.g. `getUserById` or `get_user`) to a lowercase, dash-separated name.
+func kebabCase(s string) string {
+	var b strings.Builder
+	runes := []rune(s)
+	for i, r := range runes {
+		switch {
+		case unicode.IsUpper(r):
+			if i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+				(i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+				b.WriteRune('-')
+			}
+			b.WriteRune(unicode.ToLower(r))
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		default:
+			b.WriteRune('-')
+		}
+	}
+	return repeatedDashes.ReplaceAllString(strings.Trim(b.String(), "-"), "-")
+}
+
+// isOpenAPIFile reports whether fn is one of the file names that are imported as OpenAPI specs.
+func isOpenAPIFile(fn string) bool {
+	switch strings.ToLower(fn) {
+	case "openapi.yaml", "openapi.yml", "openapi.json":
+		return true
+	}
+	return false
+}
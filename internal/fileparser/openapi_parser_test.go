@@ -0,0 +1,80 @@
+package fileparser_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/flowexec/flow/v2/internal/fileparser"
+	"github.com/flowexec/flow/v2/types/executable"
+)
+
+var _ = Describe("ExecutablesFromOpenAPI", func() {
+	var execs executable.ExecutableList
+
+	BeforeEach(func() {
+		var err error
+		execs, err = fileparser.ExecutablesFromOpenAPI("", "testdata/openapi/openapi.yaml")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	pos := func(p int) *int { return &p }
+
+	It("should create a request executable for each operation", func() {
+		refs := make([]string, 0, len(execs))
+		for _, e := range execs {
+			Expect(e.Request).NotTo(BeNil())
+			refs = append(refs, e.Verb.String()+" "+e.Name)
+		}
+		Expect(refs).To(Equal([]string{
+			"get list-users", "create user", "get user-by-id", "delete users-user-id",
+		}))
+	})
+
+	It("should set the server URL from a param", func() {
+		e := findExecutable(execs, executable.VerbGet, "", "list-users")
+		Expect(e.Request.URL).To(Equal("${USERS_API_URL}/users"))
+		Expect(e.Request.Params).To(ConsistOf(
+			executable.Parameter{EnvKey: "USERS_API_URL", Text: "https://api.example.com/v1"},
+		))
+	})
+
+	It("should map path, query and header params to args", func() {
+		list := findExecutable(execs, executable.VerbGet, "", "list-users")
+		Expect(list.Description).To(Equal("List users"))
+		Expect(list.Tags).To(Equal(executable.ExecutableTags{"generated", "openapi", "users"}))
+		Expect(list.Request.Method).To(Equal(executable.RequestExecutableTypeMethodGET))
+		Expect(list.Request.Query).To(Equal(executable.RequestExecutableTypeQuery{
+			"limit": "$LIMIT", "sort-by": "$SORT_BY",
+		}))
+		Expect(list.Request.Args).To(ConsistOf(
+			executable.Argument{Flag: "limit", EnvKey: "LIMIT", Default: "20"},
+			executable.Argument{Flag: "sort-by", EnvKey: "SORT_BY"},
+		))
+
+		get := findExecutable(execs, executable.VerbGet, "", "user-by-id")
+		Expect(get.Description).To(Equal("Get a user\n\nReturns a single user."))
+		Expect(get.Request.URL).To(Equal("${USERS_API_URL}/users/${USER_ID}"))
+		Expect(get.Request.Headers).To(Equal(executable.RequestExecutableTypeHeaders{"X-Request-Id": "$X_REQUEST_ID"}))
+		Expect(get.Request.Args).To(ConsistOf(
+			executable.Argument{Flag: "X-Request-Id", EnvKey: "X_REQUEST_ID"},
+			executable.Argument{Pos: pos(1), EnvKey: "USER_ID", Required: true, Type: executable.ArgumentTypeInt},
+		))
+
+		del := findExecutable(execs, executable.VerbDelete, "", "users-user-id")
+		Expect(del.Description).To(Equal("DELETE /users/{userId}"))
+		Expect(del.Tags).To(Equal(executable.ExecutableTags{"generated", "openapi", "users", "admin"}))
+	})
+
+	It("should send the body from an arg or file", func() {
+		create := findExecutable(execs, executable.VerbCreate, "", "user")
+		Expect(create.Request.Method).To(Equal(executable.RequestExecutableTypeMethodPOST))
+		Expect(create.Request.Body).To(Equal("$BODY"))
+		Expect(create.Request.BodyFile).To(Equal("$BODY_FILE"))
+		Expect(create.Request.Headers).To(HaveKeyWithValue("Content-Type", "application/json"))
+		Expect(create.Request.Args).To(ConsistOf(
+			executable.Argument{Flag: "body", EnvKey: "BODY", Default: `{"name":"flow","roles":["admin"]}`},
+			executable.Argument{Flag: "body-file", EnvKey: "BODY_FILE"},
+		))
+		Expect(create.Request.Validate()).To(Succeed())
+	})
+})
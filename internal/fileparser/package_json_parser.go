@@ -3,61 +3,320 @@ package fileparser
 import (
 	"encoding/json"
 	"fmt"
+	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
+	"slices"
+	"strings"
 
+	"gopkg.in/yaml.v3"
+
+	"github.com/flowexec/flow/v2/pkg/logger"
 	"github.com/flowexec/flow/v2/types/executable"
 )
 
 type packageJSON struct {
-	Scripts map[string]string `json:"scripts"`
+	Name           string            `json:"name"`
+	PackageManager string            `json:"packageManager"`
+	Scripts        map[string]string `json:"scripts"`
+	Workspaces     packageWorkspaces `json:"workspaces"`
+}
+
+// packageWorkspaces is the list of workspace package patterns. Yarn also accepts an object with a `packages` list.
+type packageWorkspaces []string
+
+// packageManager is the tool that dependencies are installed and scripts are run with.
+type packageManager struct {
+	name string
+	// runAllCmd is the command for running a script in every workspace package.
+	runAllCmd string
 }
 
-var packageJSONTags = []string{generatedTag, "npm"}
+var (
+	packageJSONTags = []string{generatedTag, "npm"}
+
+	packageManagers = map[string]packageManager{
+		"npm":   {name: "npm", runAllCmd: "npm run %s --workspaces --if-present"},
+		"yarn":  {name: "yarn", runAllCmd: "yarn workspaces foreach --all run %s"},
+		"pnpm":  {name: "pnpm", runAllCmd: "pnpm --recursive run %s"},
+		"bun":   {name: "bun", runAllCmd: "bun run --filter '*' %s"},
+		"yarn1": {name: "yarn", runAllCmd: "yarn workspaces run %s"},
+	}
+	packageLockfiles = []struct{ file, manager string }{
+		{"pnpm-lock.yaml", "pnpm"},
+		{"bun.lockb", "bun"},
+		{"bun.lock", "bun"},
+		{"yarn.lock", "yarn"},
+		{"package-lock.json", "npm"},
+	}
+)
 
-// ExecutablesFromPackageJSON parses package.json scripts and returns a list of Executables for them
+// ExecutablesFromPackageJSON parses package.json scripts and returns a list of Executables for them. Scripts are run
+// with the package manager detected from the `packageManager` field or the lockfile. When workspaces are declared,
+// the scripts of each workspace package are scoped to a namespace named after the package, and an executable is
+// added for running each of those scripts across all packages.
 func ExecutablesFromPackageJSON(wsPath, path string) (executable.ExecutableList, error) {
-	f, err := os.Open(filepath.Clean(path))
+	pkg, err := readPackageJSON(path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open package.json: %w", err)
+		return nil, err
 	}
-	defer f.Close()
 
-	var pkg packageJSON
-	dec := json.NewDecoder(f)
-	if err := dec.Decode(&pkg); err != nil {
-		return nil, fmt.Errorf("failed to decode package.json: %w", err)
+	rootDir := filepath.Dir(path)
+	pm := detectPackageManager(rootDir, pkg.PackageManager)
+	tags := packageJSONTags
+	if pm.name != "npm" {
+		tags = append(append([]string{}, packageJSONTags...), pm.name)
 	}
+	dir := executable.Directory(shortenWsPath(wsPath, rootDir))
 
 	execs := make(executable.ExecutableList, 0)
-	dir := executable.Directory(shortenWsPath(wsPath, filepath.Dir(path)))
-
-	// default npm install
 	execs = append(execs, &executable.Executable{
 		Verb:        executable.VerbInstall,
-		Aliases:     []string{"npm"},
-		Description: "Install npm dependencies",
-		Tags:        packageJSONTags,
+		Aliases:     []string{pm.name},
+		Description: fmt.Sprintf("Install %s dependencies", pm.name),
+		Tags:        tags,
 		Exec: &executable.ExecExecutableType{
 			Dir: dir,
-			Cmd: "npm install",
+			Cmd: fmt.Sprintf("%s install", pm.name),
 		},
 	})
+	rootExecs := packageScriptExecutables(pkg, pm, dir, "", tags)
+	execs = append(execs, rootExecs...)
 
+	patterns := pkg.Workspaces
+	if pm.name == "pnpm" {
+		patterns = append(patterns, pnpmWorkspacePackages(rootDir)...)
+	}
+	workspaceScripts := make(map[string]bool)
+	for _, pkgDir := range expandPackageWorkspaces(rootDir, patterns) {
+		member, err := readPackageJSON(filepath.Join(pkgDir, "package.json"))
+		if err != nil {
+			logger.Log().Warn("skipping workspace package", "dir", pkgDir, "err", err)
+			continue
+		}
+		ns := packageNamespace(member.Name, pkgDir)
+		memberDir := executable.Directory(shortenWsPath(wsPath, pkgDir))
+		execs = append(execs, packageScriptExecutables(member, pm, memberDir, ns, tags)...)
+		for script := range member.Scripts {
+			workspaceScripts[script] = true
+		}
+	}
+
+	for _, script := range sortedKeys(workspaceScripts) {
+		verb := InferVerb(script)
+		name := NormalizeName(script, verb.String())
+		if name == "" {
+			name = "all"
+		} else {
+			name += "-all"
+		}
+		// A root script with the same name takes precedence over running the script in all packages.
+		if slices.ContainsFunc(rootExecs, func(e *executable.Executable) bool {
+			return e.Verb == verb && e.Name == name
+		}) {
+			logger.Log().Warn("skipping workspace script that conflicts with a root script", "script", script)
+			continue
+		}
+		execs = append(execs, &executable.Executable{
+			Verb:        verb,
+			Name:        name,
+			Description: fmt.Sprintf("Run %s script %s in all workspace packages", pm.name, script),
+			Tags:        tags,
+			Exec: &executable.ExecExecutableType{
+				Dir: dir,
+				Cmd: fmt.Sprintf(pm.runAllCmd, script),
+			},
+		})
+	}
+	return execs, nil
+}
+
+func packageScriptExecutables(
+	pkg *packageJSON, pm packageManager, dir executable.Directory, ns string, tags []string,
+) executable.ExecutableList {
+	execs := make(executable.ExecutableList, 0, len(pkg.Scripts))
 	for name, scriptCmd := range pkg.Scripts {
 		verb := InferVerb(name)
 		execName := NormalizeName(name, verb.String())
 		e := &executable.Executable{
 			Verb:        verb,
 			Name:        execName,
-			Description: fmt.Sprintf("Run npm script %s:\n`%s`", name, scriptCmd),
-			Tags:        packageJSONTags,
+			Description: fmt.Sprintf("Run %s script %s:\n`%s`", pm.name, name, scriptCmd),
+			Tags:        tags,
 			Exec: &executable.ExecExecutableType{
 				Dir: dir,
-				Cmd: fmt.Sprintf("npm run %s", name),
+				Cmd: fmt.Sprintf("%s run %s", pm.name, name),
 			},
 		}
+		if ns != "" {
+			e.SetContext("", "", ns, "")
+		}
 		execs = append(execs, e)
 	}
-	return execs, nil
+	return execs
+}
+
+func readPackageJSON(path string) (*packageJSON, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open package.json: %w", err)
+	}
+	defer f.Close()
+
+	var pkg packageJSON
+	dec := json.NewDecoder(f)
+	if err := dec.Decode(&pkg); err != nil {
+		return nil, fmt.Errorf("failed to decode package.json: %w", err)
+	}
+	return &pkg, nil
+}
+
+// detectPackageManager returns the package manager named by the `packageManager` field (e.g. `pnpm@9.1.0`), falling
+// back to the one that owns the lockfile in dir. Projects without either use npm.
+func detectPackageManager(dir, field string) packageManager {
+	name, version, _ := strings.Cut(field, "@")
+	if name == "yarn" && strings.HasPrefix(version, "1.") {
+		return packageManagers["yarn1"]
+	} else if pm, found := packageManagers[name]; found && name != "yarn1" {
+		return pm
+	}
+	for _, lf := range packageLockfiles {
+		if _, err := os.Stat(filepath.Join(dir, lf.file)); err != nil {
+			continue
+		}
+		// Yarn 2+ projects are configured with a .yarnrc.yml.
+		if lf.manager == "yarn" {
+			if _, err := os.Stat(filepath.Join(dir, ".yarnrc.yml")); err != nil {
+				return packageManagers["yarn1"]
+			}
+		}
+		return packageManagers[lf.manager]
+	}
+	return packageManagers["npm"]
+}
+
+func pnpmWorkspacePackages(dir string) []string {
+	data, err := os.ReadFile(filepath.Clean(filepath.Join(dir, "pnpm-workspace.yaml")))
+	if err != nil {
+		return nil
+	}
+	var ws struct {
+		Packages []string `yaml:"packages"`
+	}
+	if err := yaml.Unmarshal(data, &ws); err != nil {
+		return nil
+	}
+	return ws.Packages
+}
+
+// expandPackageWorkspaces returns the directories of the workspace packages matching the patterns. Patterns starting
+// with `!` exclude packages, and a `**` path element matches any number of directories.
+func expandPackageWorkspaces(rootDir string, patterns []string) []string {
+	excluded := make(map[string]bool)
+	for _, p := range patterns {
+		if !strings.HasPrefix(p, "!") {
+			continue
+		}
+		matches := globWorkspaceDirs(rootDir, strings.TrimPrefix(p, "!"))
+		for _, m := range matches {
+			excluded[m] = true
+		}
+	}
+
+	var dirs []string
+	seen := make(map[string]bool)
+	for _, p := range patterns {
+		if strings.HasPrefix(p, "!") {
+			continue
+		}
+		matches := globWorkspaceDirs(rootDir, p)
+		for _, m := range matches {
+			if seen[m] || excluded[m] || m == rootDir {
+				continue
+			}
+			if _, err := os.Stat(filepath.Join(m, "package.json")); err != nil {
+				continue
+			}
+			seen[m] = true
+			dirs = append(dirs, m)
+		}
+	}
+	return dirs
+}
+
+// globWorkspaceDirs returns the paths in rootDir matching a workspace pattern. Unlike filepath.Glob, a `**` path
+// element matches any number of directories; node_modules and hidden directories aren't searched for those.
+func globWorkspaceDirs(rootDir, pattern string) []string {
+	pattern = filepath.ToSlash(filepath.Clean(pattern))
+	if !strings.Contains(pattern, "**") {
+		matches, _ := filepath.Glob(filepath.Join(rootDir, pattern))
+		return matches
+	}
+
+	elems := strings.Split(pattern, "/")
+	var matches []string
+	_ = filepath.WalkDir(rootDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() || p == rootDir {
+			return nil
+		}
+		if d.Name() == "node_modules" || strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+		rel, err := filepath.Rel(rootDir, p)
+		if err == nil && matchPathElems(elems, strings.Split(filepath.ToSlash(rel), "/")) {
+			matches = append(matches, p)
+		}
+		return nil
+	})
+	return matches
+}
+
+// matchPathElems reports whether the path elements match the pattern elements, where a `**` element matches zero or
+// more path elements.
+func matchPathElems(pattern, elems []string) bool {
+	if len(pattern) == 0 {
+		return len(elems) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(elems); i++ {
+			if matchPathElems(pattern[1:], elems[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(elems) == 0 {
+		return false
+	}
+	matched, err := path.Match(pattern[0], elems[0])
+	return err == nil && matched && matchPathElems(pattern[1:], elems[1:])
+}
+
+// packageNamespace returns the namespace for a workspace package's executables: the package name without its scope,
+// or the package's directory name if it has no name.
+func packageNamespace(name, dir string) string {
+	if name == "" {
+		name = filepath.Base(dir)
+	}
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	return NormalizeName(name, "")
+}
+
+func (w *packageWorkspaces) UnmarshalJSON(data []byte) error {
+	var patterns []string
+	if err := json.Unmarshal(data, &patterns); err == nil {
+		*w = patterns
+		return nil
+	}
+	var obj struct {
+		Packages []string `json:"packages"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	*w = obj.Packages
+	return nil
 }
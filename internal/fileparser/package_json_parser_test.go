@@ -8,6 +8,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/flowexec/flow/v2/internal/fileparser"
+	"github.com/flowexec/flow/v2/types/executable"
 )
 
 var _ = Describe("ExecutablesFromPackageJSON", func() {
@@ -42,3 +43,61 @@ var _ = Describe("ExecutablesFromPackageJSON", func() {
 		}
 	})
 })
+
+var _ = Describe("ExecutablesFromPackageJSON with workspaces", func() {
+	It("should use the package manager from the packageManager field", func() {
+		execs, err := fileparser.ExecutablesFromPackageJSON("", "testdata/npm-workspaces/package.json")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(execs).To(HaveLen(9))
+
+		install := findExecutable(execs, executable.VerbInstall, "", "")
+		Expect(install.Exec.Cmd).To(Equal("pnpm install"))
+		Expect(install.Aliases).To(ConsistOf("pnpm"))
+		Expect(install.Tags).To(ConsistOf("generated", "npm", "pnpm"))
+		Expect(findExecutable(execs, executable.VerbLint, "", "").Exec.Cmd).To(Equal("pnpm run lint"))
+	})
+
+	It("should scope workspace package scripts to the package's namespace", func() {
+		execs, err := fileparser.ExecutablesFromPackageJSON("", "testdata/npm-workspaces/package.json")
+		Expect(err).NotTo(HaveOccurred())
+
+		app := findExecutable(execs, executable.VerbBuild, "web", "app")
+		Expect(app.Exec.Cmd).To(Equal("pnpm run build:app"))
+		Expect(string(app.Exec.Dir)).To(HaveSuffix("packages/web"))
+		Expect(findExecutable(execs, executable.VerbBuild, "api", "").Exec.Cmd).To(Equal("pnpm run build"))
+		for _, e := range execs {
+			Expect(e.Namespace()).NotTo(Equal("docs"))
+		}
+	})
+
+	It("should add executables that run a script in all workspace packages", func() {
+		execs, err := fileparser.ExecutablesFromPackageJSON("", "testdata/npm-workspaces/package.json")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(findExecutable(execs, executable.VerbTest, "", "all").Exec.Cmd).To(Equal("pnpm --recursive run test"))
+		Expect(findExecutable(execs, executable.VerbBuild, "", "all").Exec.Cmd).To(Equal("pnpm --recursive run build"))
+		Expect(findExecutable(execs, executable.VerbBuild, "", "app-all").Exec.Cmd).
+			To(Equal("pnpm --recursive run build:app"))
+	})
+
+	It("should detect the package manager from the lockfile", func() {
+		execs, err := fileparser.ExecutablesFromPackageJSON("", "testdata/yarn-lock/package.json")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(execs).To(HaveLen(4))
+
+		Expect(findExecutable(execs, executable.VerbInstall, "", "").Exec.Cmd).To(Equal("yarn install"))
+		Expect(findExecutable(execs, executable.VerbStart, "", "dev").Exec.Cmd).To(Equal("yarn run dev"))
+		Expect(findExecutable(execs, executable.VerbBuild, "site", "").Exec.Cmd).To(Equal("yarn run build"))
+		Expect(findExecutable(execs, executable.VerbBuild, "", "all").Exec.Cmd).To(Equal("yarn workspaces run build"))
+	})
+
+	It("should expand nested workspace patterns and skip invalid packages", func() {
+		execs, err := fileparser.ExecutablesFromPackageJSON("", "testdata/npm-nested/package.json")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(execs).To(HaveLen(3))
+
+		Expect(findExecutable(execs, executable.VerbTest, "cli", "").Exec.Cmd).To(Equal("npm run test"))
+		// The root script takes precedence over running test in all packages.
+		Expect(findExecutable(execs, executable.VerbTest, "", "all").Exec.Cmd).To(Equal("npm run test:all"))
+	})
+})
@@ -0,0 +1,187 @@
+package fileparser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/flowexec/flow/v2/types/executable"
+)
+
+type pyproject struct {
+	Project struct {
+		Scripts map[string]string `toml:"scripts"`
+	} `toml:"project"`
+	Tool struct {
+		Poetry struct {
+			Scripts map[string]any `toml:"scripts"`
+		} `toml:"poetry"`
+		Pdm struct {
+			Scripts map[string]any `toml:"scripts"`
+		} `toml:"pdm"`
+		Hatch struct {
+			Envs map[string]struct {
+				Scripts map[string]any `toml:"scripts"`
+			} `toml:"envs"`
+		} `toml:"hatch"`
+	} `toml:"tool"`
+}
+
+// pythonTool is the project management tool that scripts are run and dependencies are installed with.
+type pythonTool struct {
+	name       string
+	installCmd string
+	// runCmd is prefixed to a script to run it in the project's environment.
+	runCmd string
+}
+
+var (
+	pythonTools = map[string]pythonTool{
+		"poetry": {name: "poetry", installCmd: "poetry install", runCmd: "poetry run"},
+		"pdm":    {name: "pdm", installCmd: "pdm install", runCmd: "pdm run"},
+		"hatch":  {name: "hatch", installCmd: "hatch env create", runCmd: "hatch run"},
+		"uv":     {name: "uv", installCmd: "uv sync", runCmd: "uv run"},
+		"pip":    {name: "pip", installCmd: "pip install -e ."},
+	}
+	pythonLockfiles = []struct{ file, tool string }{
+		{"poetry.lock", "poetry"},
+		{"pdm.lock", "pdm"},
+		{"uv.lock", "uv"},
+	}
+)
+
+// ExecutablesFromPyproject parses a pyproject.toml and returns an Executable for each of its scripts, along with an
+// executable for installing the project. The tool used to run the scripts is detected from the project's lockfile or
+// tool configuration.
+func ExecutablesFromPyproject(wsPath, path string) (executable.ExecutableList, error) {
+	var pp pyproject
+	md, err := toml.DecodeFile(filepath.Clean(path), &pp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode pyproject.toml: %w", err)
+	}
+
+	tool := detectPythonTool(filepath.Dir(path), md)
+	tags := []string{generatedTag, "python", tool.name}
+	dir := executable.Directory(shortenWsPath(wsPath, filepath.Dir(path)))
+
+	execs := executable.ExecutableList{{
+		Verb:        executable.VerbInstall,
+		Aliases:     []string{tool.name},
+		Description: fmt.Sprintf("Install the project's dependencies with %s", tool.name),
+		Tags:        tags,
+		Exec:        &executable.ExecExecutableType{Dir: dir, Cmd: tool.installCmd},
+	}}
+	// Executables aren't given a workspace until they're imported, so they're told apart by verb and name.
+	seen := map[string]bool{executable.VerbInstall.String(): true}
+	add := func(name, cmd, description string) {
+		verb := InferVerb(name)
+		e := &executable.Executable{
+			Verb:        verb,
+			Name:        NormalizeName(name, verb.String()),
+			Description: description,
+			Tags:        tags,
+			Exec:        &executable.ExecExecutableType{Dir: dir, Cmd: cmd},
+		}
+		key := strings.TrimSpace(fmt.Sprintf("%s %s", e.Verb, e.Name))
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		execs = append(execs, e)
+	}
+
+	switch tool.name {
+	case "pdm":
+		for _, name := range sortedKeys(pp.Tool.Pdm.Scripts) {
+			// Keys starting with an underscore configure scripts rather than define them.
+			if strings.HasPrefix(name, "_") {
+				continue
+			}
+			add(name, fmt.Sprintf("pdm run %s", name), pdmScriptDescription(name, pp.Tool.Pdm.Scripts[name]))
+		}
+	case "hatch":
+		for _, env := range sortedKeys(pp.Tool.Hatch.Envs) {
+			for _, script := range sortedKeys(pp.Tool.Hatch.Envs[env].Scripts) {
+				name, target := script, script
+				if env != "default" {
+					name, target = env+"-"+script, env+":"+script
+				}
+				add(name, fmt.Sprintf("hatch run %s", target), fmt.Sprintf(
+					"Run hatch script %s:\n`%s`", target, scriptCmdString(pp.Tool.Hatch.Envs[env].Scripts[script]),
+				))
+			}
+		}
+	}
+
+	// Entry points are installed into the project's environment, so they're run by name.
+	entryPoints := make(map[string]string, len(pp.Project.Scripts)+len(pp.Tool.Poetry.Scripts))
+	for name, ref := range pp.Project.Scripts {
+		entryPoints[name] = ref
+	}
+	for name, ref := range pp.Tool.Poetry.Scripts {
+		entryPoints[name] = scriptCmdString(ref)
+	}
+	for _, name := range sortedKeys(entryPoints) {
+		cmd := strings.TrimSpace(fmt.Sprintf("%s %s", tool.runCmd, name))
+		add(name, cmd, fmt.Sprintf("Run script %s:\n`%s`", name, entryPoints[name]))
+	}
+	return execs, nil
+}
+
+func detectPythonTool(dir string, md toml.MetaData) pythonTool {
+	for _, lf := range pythonLockfiles {
+		if _, err := os.Stat(filepath.Join(dir, lf.file)); err == nil {
+			return pythonTools[lf.tool]
+		}
+	}
+	for _, name := range []string{"poetry", "pdm", "hatch", "uv"} {
+		if md.IsDefined("tool", name) {
+			return pythonTools[name]
+		}
+	}
+	return pythonTools["pip"]
+}
+
+func pdmScriptDescription(name string, script any) string {
+	if table, ok := script.(map[string]any); ok {
+		if help, ok := table["help"].(string); ok && help != "" {
+			return help
+		}
+	}
+	return fmt.Sprintf("Run pdm script %s:\n`%s`", name, scriptCmdString(script))
+}
+
+// scriptCmdString returns a readable form of a script definition. Scripts can be defined as a string, a list of
+// commands or a table (e.g. `{cmd = "..."}`, `{call = "..."}`, `{reference = "..."}`).
+func scriptCmdString(script any) string {
+	switch s := script.(type) {
+	case string:
+		return s
+	case []any:
+		parts := make([]string, 0, len(s))
+		for _, p := range s {
+			parts = append(parts, scriptCmdString(p))
+		}
+		return strings.Join(parts, " && ")
+	case map[string]any:
+		for _, key := range []string{"cmd", "shell", "call", "composite", "reference", "callable"} {
+			if v, ok := s[key]; ok {
+				return scriptCmdString(v)
+			}
+		}
+	}
+	return fmt.Sprintf("%v", script)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
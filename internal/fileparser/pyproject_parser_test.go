@@ -0,0 +1,50 @@
+package fileparser_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/flowexec/flow/v2/internal/fileparser"
+	"github.com/flowexec/flow/v2/types/executable"
+)
+
+var _ = Describe("ExecutablesFromPyproject", func() {
+	It("should run pdm scripts with pdm", func() {
+		execs, err := fileparser.ExecutablesFromPyproject("", "testdata/pyproject/pdm/pyproject.toml")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(execs).To(HaveLen(5))
+
+		install := findExecutable(execs, executable.VerbInstall, "", "")
+		Expect(install.Exec.Cmd).To(Equal("pdm install"))
+		Expect(install.Aliases).To(ConsistOf("pdm"))
+		Expect(install.Tags).To(ConsistOf("generated", "python", "pdm"))
+
+		lint := findExecutable(execs, executable.VerbLint, "", "")
+		Expect(lint.Exec.Cmd).To(Equal("pdm run lint"))
+		Expect(lint.Description).To(Equal("Lint the project"))
+		Expect(findExecutable(execs, executable.VerbTest, "", "unit").Exec.Cmd).To(Equal("pdm run test-unit"))
+		Expect(findExecutable(execs, executable.VerbStart, "", "").Exec.Cmd).To(Equal("pdm run start"))
+		Expect(findExecutable(execs, executable.VerbExec, "", "app").Exec.Cmd).To(Equal("pdm run app"))
+	})
+
+	It("should run hatch scripts in their environment", func() {
+		execs, err := fileparser.ExecutablesFromPyproject("", "testdata/pyproject/hatch/pyproject.toml")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(execs).To(HaveLen(4))
+
+		Expect(findExecutable(execs, executable.VerbInstall, "", "").Exec.Cmd).To(Equal("hatch env create"))
+		Expect(findExecutable(execs, executable.VerbTest, "", "").Exec.Cmd).To(Equal("hatch run test"))
+		build := findExecutable(execs, executable.VerbExec, "", "docs-build")
+		Expect(build.Exec.Cmd).To(Equal("hatch run docs:build"))
+		Expect(build.Description).To(ContainSubstring("mkdocs build --clean && echo done"))
+	})
+
+	It("should detect the tool from the lockfile", func() {
+		execs, err := fileparser.ExecutablesFromPyproject("", "testdata/pyproject/uv/pyproject.toml")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(execs).To(HaveLen(3))
+
+		Expect(findExecutable(execs, executable.VerbInstall, "", "").Exec.Cmd).To(Equal("uv sync"))
+		Expect(findExecutable(execs, executable.VerbExec, "", "migrate-db").Exec.Cmd).To(Equal("uv run migrate-db"))
+	})
+})
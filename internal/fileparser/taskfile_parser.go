@@ -0,0 +1,454 @@
+package fileparser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/flowexec/flow/v2/types/common"
+	"github.com/flowexec/flow/v2/types/executable"
+)
+
+type taskfile struct {
+	Env      map[string]any             `yaml:"env"`
+	Dotenv   []string                   `yaml:"dotenv"`
+	Includes map[string]taskfileInclude `yaml:"includes"`
+	Tasks    map[string]*taskfileTask   `yaml:"tasks"`
+}
+
+type taskfileInclude struct {
+	Taskfile string `yaml:"taskfile"`
+	Dir      string `yaml:"dir"`
+	Internal bool   `yaml:"internal"`
+	Optional bool   `yaml:"optional"`
+	Flatten  bool   `yaml:"flatten"`
+}
+
+type taskfileTask struct {
+	Desc          string           `yaml:"desc"`
+	Summary       string           `yaml:"summary"`
+	Aliases       []string         `yaml:"aliases"`
+	Dir           string           `yaml:"dir"`
+	Internal      bool             `yaml:"internal"`
+	Deps          []taskfileDep    `yaml:"deps"`
+	Cmds          []taskfileCmd    `yaml:"cmds"`
+	Vars          yaml.Node        `yaml:"vars"`
+	Requires      taskfileRequires `yaml:"requires"`
+	Env           map[string]any   `yaml:"env"`
+	Dotenv        []string         `yaml:"dotenv"`
+	Preconditions []any            `yaml:"preconditions"`
+	Sources       []any            `yaml:"sources"`
+	Status        []string         `yaml:"status"`
+	Platforms     []string         `yaml:"platforms"`
+	Prompt        any              `yaml:"prompt"`
+}
+
+type taskfileDep struct {
+	Task string         `yaml:"task"`
+	Vars map[string]any `yaml:"vars"`
+}
+
+type taskfileCmd struct {
+	Cmd     string `yaml:"cmd"`
+	Complex bool   `yaml:"-"`
+}
+
+type taskfileRequires struct {
+	Vars []taskfileRequiredVar `yaml:"vars"`
+}
+
+type taskfileRequiredVar struct {
+	Name string `yaml:"name"`
+}
+
+type taskfileVar struct {
+	name       string
+	defaultVal string
+	required   bool
+}
+
+// taskfileEntry is a task along with the Taskfile (and include) it was defined in.
+type taskfileEntry struct {
+	// path is the name of the task as passed to `task`, including the namespaces of its includes (e.g. docs:build).
+	path     string
+	name     string
+	ns       string
+	task     *taskfileTask
+	dir      string
+	internal bool
+	// simpleEnv is false when the Taskfile sets environment that the task's commands would depend on.
+	simpleEnv bool
+	vars      []taskfileVar
+	ref       executable.Ref
+}
+
+var taskfileTags = []string{generatedTag, "task"}
+
+// ExecutablesFromTaskfile parses a Taskfile and returns an Executable for each of its tasks, including the tasks of
+// included Taskfiles. Tasks are run with `task <name>` from the Taskfile's directory, and tasks of included Taskfiles
+// are scoped to a namespace named after the include.
+func ExecutablesFromTaskfile(wsPath, path string) (executable.ExecutableList, error) {
+	rootDir := filepath.Dir(path)
+	entries := make(map[string]*taskfileEntry)
+	if err := collectTaskfileEntries(path, rootDir, nil, false, entries, map[string]bool{}); err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(entries))
+	for p := range entries {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	execs := make(executable.ExecutableList, 0, len(entries))
+	for _, p := range paths {
+		execs = append(execs, taskfileExecutables(entries[p], entries, wsPath, rootDir)...)
+	}
+	return execs, nil
+}
+
+func loadTaskfile(path string) (*taskfile, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open Taskfile: %w", err)
+	}
+	var tf taskfile
+	if err := yaml.Unmarshal(data, &tf); err != nil {
+		return nil, fmt.Errorf("failed to decode Taskfile: %w", err)
+	}
+	return &tf, nil
+}
+
+//nolint:gocognit
+func collectTaskfileEntries(
+	path, workDir string, prefix []string, internal bool, entries map[string]*taskfileEntry, including map[string]bool,
+) error {
+	if including[path] {
+		return fmt.Errorf("Taskfile include cycle detected at %s", path)
+	}
+	including[path] = true
+	defer delete(including, path)
+
+	tf, err := loadTaskfile(path)
+	if err != nil {
+		return err
+	}
+	simpleEnv := len(tf.Env) == 0 && len(tf.Dotenv) == 0
+	for name, t := range tf.Tasks {
+		if t == nil {
+			t = &taskfileTask{}
+		}
+		taskPath := strings.Join(append(append([]string{}, prefix...), name), ":")
+		verb := InferVerb(name)
+		ns := strings.Join(prefix, ".")
+		normalized := NormalizeName(name, verb.String())
+		ref := executable.NewRef(normalized, verb)
+		if ns != "" {
+			ref = executable.NewRef(ns+":"+normalized, verb)
+		}
+		dir := workDir
+		if t.Dir != "" && !strings.Contains(t.Dir, "{{") {
+			dir = filepath.Join(workDir, t.Dir)
+		}
+		entries[taskPath] = &taskfileEntry{
+			path:      taskPath,
+			name:      name,
+			ns:        ns,
+			task:      t,
+			dir:       dir,
+			internal:  internal || t.Internal,
+			simpleEnv: simpleEnv,
+			vars:      taskfileVars(t),
+			ref:       ref,
+		}
+	}
+
+	baseDir := filepath.Dir(path)
+	for key, inc := range tf.Includes {
+		incPath := inc.Taskfile
+		if strings.Contains(incPath, "{{") {
+			if inc.Optional {
+				continue
+			}
+			return fmt.Errorf("unable to resolve templated Taskfile include %s", key)
+		}
+		if !filepath.IsAbs(incPath) {
+			incPath = filepath.Join(baseDir, incPath)
+		}
+		incPath, err = resolveTaskfilePath(incPath)
+		if err != nil {
+			if inc.Optional {
+				continue
+			}
+			return fmt.Errorf("unable to resolve Taskfile include %s: %w", key, err)
+		}
+
+		incDir := filepath.Dir(incPath)
+		if inc.Dir != "" {
+			incDir = filepath.Join(baseDir, inc.Dir)
+		}
+		incPrefix := prefix
+		if !inc.Flatten {
+			incPrefix = append(append([]string{}, prefix...), key)
+		}
+		if err := collectTaskfileEntries(
+			incPath, incDir, incPrefix, internal || inc.Internal, entries, including,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveTaskfilePath returns the Taskfile at path, looking up the default Taskfile names when path is a directory.
+func resolveTaskfilePath(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	if !info.IsDir() {
+		return path, nil
+	}
+	for _, name := range []string{"Taskfile.yml", "taskfile.yml", "Taskfile.yaml", "taskfile.yaml"} {
+		if _, err := os.Stat(filepath.Join(path, name)); err == nil {
+			return filepath.Join(path, name), nil
+		}
+	}
+	return "", fmt.Errorf("no Taskfile found in %s", path)
+}
+
+func taskfileExecutables(
+	entry *taskfileEntry, entries map[string]*taskfileEntry, wsPath, rootDir string,
+) executable.ExecutableList {
+	t := entry.task
+	verb := InferVerb(entry.name)
+	e := &executable.Executable{
+		Verb:        verb,
+		Name:        NormalizeName(entry.name, verb.String()),
+		Description: strings.TrimSpace(strings.Join(nonEmpty(t.Desc, t.Summary), "\n\n")),
+		Tags:        taskfileTags,
+	}
+	for _, alias := range t.Aliases {
+		e.Aliases = append(e.Aliases, NormalizeName(alias, ""))
+	}
+	if entry.internal {
+		v := executable.ExecutableVisibility(common.VisibilityInternal)
+		e.Visibility = &v
+	}
+	if entry.ns != "" {
+		e.SetContext("", "", entry.ns, "")
+	}
+
+	if len(t.Deps) == 0 || !entry.runsDirectly() {
+		args := make(executable.ArgumentList, 0, len(entry.vars))
+		cmd := []string{"task", entry.path}
+		for _, v := range entry.vars {
+			envKey := importArgEnvKey(v.name, "TASK_")
+			args = append(args, executable.Argument{
+				EnvKey:   envKey,
+				Flag:     taskfileArgFlag(v.name),
+				Default:  v.defaultVal,
+				Required: v.required,
+			})
+			// Empty values leave the variable's default to task.
+			cmd = append(cmd, fmt.Sprintf(`${%s:+%s="$%s"}`, envKey, v.name, envKey))
+		}
+		e.Exec = &executable.ExecExecutableType{
+			Dir:  executable.Directory(shortenWsPath(wsPath, rootDir)),
+			Cmd:  strings.Join(cmd, " "),
+			Args: args,
+		}
+		return executable.ExecutableList{e}
+	}
+
+	// Task would run the dependencies again, so tasks that don't rely on task's templating or environment have
+	// their commands run directly after the dependencies.
+	execs := make(executable.SerialRefConfigList, 0, len(t.Cmds)+1)
+	result := executable.ExecutableList{e}
+	if len(t.Deps) == 1 {
+		ref, args, cmd := taskfileDepRef(t.Deps[0], entry, entries)
+		execs = append(execs, executable.SerialRefConfig{Ref: ref, Args: args, Cmd: cmd})
+	} else {
+		depExecs := make(executable.ParallelRefConfigList, 0, len(t.Deps))
+		for _, dep := range t.Deps {
+			ref, args, cmd := taskfileDepRef(dep, entry, entries)
+			depExecs = append(depExecs, executable.ParallelRefConfig{Ref: ref, Args: args, Cmd: cmd})
+		}
+		deps, depsRef := parallelDepsExecutable(
+			e,
+			fmt.Sprintf("Run the dependencies of task %s in parallel", entry.path),
+			executable.Directory(shortenWsPath(wsPath, rootDir)),
+			depExecs,
+		)
+		execs = append(execs, executable.SerialRefConfig{Ref: depsRef})
+		result = append(result, deps)
+	}
+	for _, c := range t.Cmds {
+		execs = append(execs, executable.SerialRefConfig{Cmd: c.Cmd})
+	}
+	e.Serial = &executable.SerialExecutableType{
+		Dir:   executable.Directory(shortenWsPath(wsPath, entry.dir)),
+		Execs: execs,
+	}
+	return result
+}
+
+// runsDirectly reports whether the task's commands can be run without task. Commands that use templating, call other
+// tasks or depend on the task's vars, env or up-to-date checks have to be run by task.
+func (entry *taskfileEntry) runsDirectly() bool {
+	t := entry.task
+	if !entry.simpleEnv || len(entry.vars) > 0 || len(t.Env) > 0 || len(t.Dotenv) > 0 ||
+		len(t.Preconditions) > 0 || len(t.Sources) > 0 || len(t.Status) > 0 || len(t.Platforms) > 0 ||
+		t.Prompt != nil || strings.Contains(t.Dir, "{{") {
+		return false
+	}
+	for _, c := range t.Cmds {
+		if c.Complex || strings.Contains(c.Cmd, "{{") {
+			return false
+		}
+	}
+	return true
+}
+
+// taskfileDepRef returns the ref to the executable of a dependency. Dependencies that aren't known tasks are left
+// to task to resolve.
+func taskfileDepRef(
+	dep taskfileDep, entry *taskfileEntry, entries map[string]*taskfileEntry,
+) (ref executable.Ref, args []string, cmd string) {
+	depPath := strings.TrimPrefix(dep.Task, ":")
+	if !strings.HasPrefix(dep.Task, ":") {
+		if i := strings.LastIndex(entry.path, ":"); i >= 0 {
+			depPath = entry.path[:i+1] + dep.Task
+		}
+	}
+	target, found := entries[depPath]
+	if !found {
+		return "", nil, "task " + depPath
+	}
+	for _, v := range target.vars {
+		if value, set := dep.Vars[v.name]; set {
+			args = append(args, fmt.Sprintf("%s=%v", taskfileArgFlag(v.name), value))
+		}
+	}
+	return target.ref, args, ""
+}
+
+func taskfileArgFlag(varName string) string {
+	return strings.ToLower(strings.ReplaceAll(varName, "_", "-"))
+}
+
+// taskfileVars returns the task's variables that can be set from the command line. Static values become the
+// variable's default while dynamic (`sh`) values are left to task.
+func taskfileVars(t *taskfileTask) []taskfileVar {
+	var vars []taskfileVar
+	seen := make(map[string]bool)
+	if t.Vars.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(t.Vars.Content); i += 2 {
+			name, value := t.Vars.Content[i].Value, t.Vars.Content[i+1]
+			v := taskfileVar{name: name}
+			switch value.Kind {
+			case yaml.ScalarNode:
+				if !strings.Contains(value.Value, "{{") {
+					v.defaultVal = value.Value
+				}
+			case yaml.MappingNode:
+				var dyn struct {
+					Sh string `yaml:"sh"`
+				}
+				if err := value.Decode(&dyn); err != nil || dyn.Sh == "" {
+					continue
+				}
+			default:
+				continue
+			}
+			seen[name] = true
+			vars = append(vars, v)
+		}
+	}
+	for _, r := range t.Requires.Vars {
+		if r.Name == "" || seen[r.Name] {
+			continue
+		}
+		seen[r.Name] = true
+		vars = append(vars, taskfileVar{name: r.Name, required: true})
+	}
+	return vars
+}
+
+func nonEmpty(values ...string) []string {
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			out = append(out, strings.TrimSpace(v))
+		}
+	}
+	return out
+}
+
+// UnmarshalYAML supports the short forms of a task: a single command or a list of commands.
+func (t *taskfileTask) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		t.Cmds = []taskfileCmd{{Cmd: node.Value}}
+		return nil
+	case yaml.SequenceNode:
+		return node.Decode(&t.Cmds)
+	}
+	type plain taskfileTask
+	return node.Decode((*plain)(t))
+}
+
+// UnmarshalYAML supports the short form of an include: the path to the included Taskfile.
+func (i *taskfileInclude) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		i.Taskfile = node.Value
+		return nil
+	}
+	type plain taskfileInclude
+	return node.Decode((*plain)(i))
+}
+
+// UnmarshalYAML supports the short form of a dependency: the name of the task.
+func (d *taskfileDep) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		d.Task = node.Value
+		return nil
+	}
+	type plain taskfileDep
+	return node.Decode((*plain)(d))
+}
+
+// UnmarshalYAML decodes a command. Commands other than a plain `cmd` (task calls, defers, loops) are marked complex.
+func (c *taskfileCmd) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		c.Cmd = node.Value
+		return nil
+	}
+	var fields map[string]any
+	if err := node.Decode(&fields); err != nil {
+		return err
+	}
+	cmd, _ := fields["cmd"].(string)
+	c.Cmd = cmd
+	for key := range fields {
+		if key != "cmd" && key != "silent" {
+			c.Complex = true
+		}
+	}
+	c.Complex = c.Complex || cmd == ""
+	return nil
+}
+
+// UnmarshalYAML supports both the list and the `name` forms of required vars.
+func (r *taskfileRequiredVar) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		r.Name = node.Value
+		return nil
+	}
+	type plain taskfileRequiredVar
+	return node.Decode((*plain)(r))
+}
@@ -0,0 +1,82 @@
+package fileparser_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/flowexec/flow/v2/internal/fileparser"
+	"github.com/flowexec/flow/v2/types/common"
+	"github.com/flowexec/flow/v2/types/executable"
+)
+
+var _ = Describe("ExecutablesFromTaskfile", func() {
+	const taskfile = "testdata/taskfile/Taskfile.yml"
+
+	var execs executable.ExecutableList
+
+	BeforeEach(func() {
+		var err error
+		execs, err = fileparser.ExecutablesFromTaskfile("", taskfile)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should create an executable for each task and included task", func() {
+		Expect(execs).To(HaveLen(10))
+	})
+
+	It("should map task vars to args", func() {
+		build := findExecutable(execs, executable.VerbBuild, "", "")
+		Expect(build.Description).To(Equal("Build the application\n\nBuilds the binary for the given target."))
+		Expect(build.Aliases).To(ConsistOf("b"))
+		Expect(build.Tags).To(ConsistOf("generated", "task"))
+		Expect(build.Exec.Cmd).To(Equal(`task build ${TARGET:+TARGET="$TARGET"} ${GIT_SHA:+GIT_SHA="$GIT_SHA"}`))
+		Expect(build.Exec.Args).To(HaveLen(2))
+		Expect(build.Exec.Args[0].EnvKey).To(Equal("TARGET"))
+		Expect(build.Exec.Args[0].Flag).To(Equal("target"))
+		Expect(build.Exec.Args[0].Default).To(Equal("debug"))
+		Expect(build.Exec.Args[1].Flag).To(Equal("git-sha"))
+		Expect(build.Exec.Args[1].Default).To(BeEmpty())
+
+		release := findExecutable(execs, executable.VerbRelease, "", "")
+		Expect(release.Exec.Cmd).To(Equal(`task release ${CHANNEL:+CHANNEL="$CHANNEL"}`))
+		Expect(release.Exec.Args[0].Required).To(BeTrue())
+	})
+
+	It("should map internal tasks to internal executables", func() {
+		setup := findExecutable(execs, executable.VerbInstall, "", "setup-env")
+		Expect(setup.Visibility).To(HaveValue(Equal(executable.ExecutableVisibility(common.VisibilityInternal))))
+	})
+
+	It("should run task deps in parallel before the task", func() {
+		test := findExecutable(execs, executable.VerbTest, "", "")
+		Expect(test.Serial).NotTo(BeNil())
+		Expect(string(test.Serial.Dir)).To(HaveSuffix("testdata/taskfile/pkg"))
+		Expect(test.Serial.Execs).To(HaveLen(2))
+		Expect(test.Serial.Execs[0].Ref).To(Equal(executable.Ref("test deps")))
+		Expect(test.Serial.Execs[1].Cmd).To(Equal("go test ./..."))
+
+		deps := findExecutable(execs, executable.VerbTest, "", "deps")
+		Expect(deps.Visibility).To(HaveValue(Equal(executable.ExecutableVisibility(common.VisibilityInternal))))
+		Expect(deps.Parallel.Execs).To(HaveLen(2))
+		Expect(deps.Parallel.Execs[0].Ref).To(Equal(executable.Ref("lint")))
+		Expect(deps.Parallel.Execs[1].Ref).To(Equal(executable.Ref("generate")))
+	})
+
+	It("should leave deps of templated tasks to task", func() {
+		release := findExecutable(execs, executable.VerbRelease, "", "")
+		Expect(release.Serial).To(BeNil())
+		Expect(release.Exec).NotTo(BeNil())
+	})
+
+	It("should map included Taskfiles to namespaces", func() {
+		docsBuild := findExecutable(execs, executable.VerbBuild, "docs", "")
+		Expect(docsBuild.Description).To(Equal("Build the docs"))
+		Expect(docsBuild.Exec.Cmd).To(Equal("task docs:build"))
+
+		deps := findExecutable(execs, executable.VerbPublish, "docs", "deps")
+		Expect(deps.Parallel.Execs[0].Ref).To(Equal(executable.Ref("build docs:")))
+		Expect(deps.Parallel.Execs[1].Ref).To(Equal(executable.Ref("lint")))
+		Expect(findExecutable(execs, executable.VerbPublish, "docs", "").Serial.Execs[0].Ref).
+			To(Equal(executable.Ref("publish docs:deps")))
+	})
+})
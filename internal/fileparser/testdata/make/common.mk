@@ -0,0 +1,6 @@
+VERSION ?= 0.1.0
+GOFLAGS ?= $(shell go env GOFLAGS)
+
+.PHONY: generate
+generate: ## Generate code
+	go generate ./...
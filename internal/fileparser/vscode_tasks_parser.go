@@ -0,0 +1,470 @@
+package fileparser
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/flowexec/flow/v2/internal/utils"
+	"github.com/flowexec/flow/v2/types/common"
+	"github.com/flowexec/flow/v2/types/executable"
+)
+
+type vscodeTasks struct {
+	Tasks  []vscodeTask  `json:"tasks"`
+	Inputs []vscodeInput `json:"inputs"`
+}
+
+type vscodeTask struct {
+	Label        string          `json:"label"`
+	Type         string          `json:"type"`
+	Command      string          `json:"command"`
+	Script       string          `json:"script"`
+	Args         []vscodeArg     `json:"args"`
+	Detail       string          `json:"detail"`
+	Hide         bool            `json:"hide"`
+	Group        vscodeGroup     `json:"group"`
+	DependsOn    vscodeDependsOn `json:"dependsOn"`
+	DependsOrder string          `json:"dependsOrder"`
+	Options      struct {
+		Cwd string            `json:"cwd"`
+		Env map[string]string `json:"env"`
+	} `json:"options"`
+}
+
+type vscodeInput struct {
+	ID          string      `json:"id"`
+	Type        string      `json:"type"`
+	Description string      `json:"description"`
+	Default     string      `json:"default"`
+	Options     []vscodeArg `json:"options"`
+}
+
+// vscodeArg is a task argument or pick option. Both can be a string or an object with a value.
+type vscodeArg string
+
+// vscodeGroup is the kind of group that a task belongs to (e.g. build or test).
+type vscodeGroup string
+
+// vscodeDependsOn is the labels of the tasks that a task depends on.
+type vscodeDependsOn []string
+
+var (
+	vscodeTags = []string{generatedTag, "vscode"}
+	// e.g. "${workspaceFolder}", "${env:HOME}", "${input:environment}"
+	vscodeVariable  = regexp.MustCompile(`\$\{([a-zA-Z]+)(?::([^}]+))?\}`)
+	invalidEnvChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+)
+
+// ExecutablesFromVSCodeTasks parses a VS Code tasks.json and returns an Executable for each of its shell, process and
+// npm tasks. Tasks with dependencies run them as serial or parallel refs depending on the task's `dependsOrder`, and
+// the `pickString` and `promptString` inputs that a task uses become prompt params.
+func ExecutablesFromVSCodeTasks(wsPath, path string) (executable.ExecutableList, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open tasks.json: %w", err)
+	}
+	var tasks vscodeTasks
+	if err := json.Unmarshal(stripJSONC(data), &tasks); err != nil {
+		return nil, fmt.Errorf("failed to decode tasks.json: %w", err)
+	}
+
+	folder := filepath.Dir(path)
+	if filepath.Base(folder) == ".vscode" {
+		folder = filepath.Dir(folder)
+	}
+	vars := &vscodeVariables{wsPath: wsPath, folder: folder, inputs: make(map[string]vscodeInput)}
+	for _, in := range tasks.Inputs {
+		vars.inputs[in.ID] = in
+	}
+
+	refs := make(map[string]executable.Ref, len(tasks.Tasks))
+	for _, t := range tasks.Tasks {
+		verb, name := vscodeTaskName(t.Label)
+		refs[t.Label] = executable.NewRef(name, verb)
+	}
+
+	execs := make(executable.ExecutableList, 0, len(tasks.Tasks))
+	for _, t := range tasks.Tasks {
+		execs = append(execs, vscodeTaskExecutables(t, vars, refs)...)
+	}
+	return execs, nil
+}
+
+func vscodeTaskName(label string) (executable.Verb, string) {
+	label = strings.ToLower(strings.TrimSpace(label))
+	verb := InferVerb(label)
+	return verb, NormalizeName(label, verb.String())
+}
+
+//nolint:gocognit
+func vscodeTaskExecutables(
+	t vscodeTask, vars *vscodeVariables, refs map[string]executable.Ref,
+) executable.ExecutableList {
+	cmd := vscodeTaskCmd(t, vars)
+	var deps []executable.Ref
+	for _, label := range t.DependsOn {
+		if ref, found := refs[label]; found {
+			deps = append(deps, ref)
+		}
+	}
+	if cmd == "" && len(deps) == 0 {
+		return nil
+	}
+
+	verb, name := vscodeTaskName(t.Label)
+	e := &executable.Executable{
+		Verb:        verb,
+		Name:        name,
+		Description: t.Detail,
+		Tags:        vscodeTags,
+	}
+	if t.Group != "" {
+		e.Tags = append(append([]string{}, vscodeTags...), string(t.Group))
+	}
+	if t.Hide {
+		v := executable.ExecutableVisibility(common.VisibilityInternal)
+		e.Visibility = &v
+	}
+
+	// Values that reference env vars are set by the command so that they're resolved when it runs.
+	var setup []string
+	dir := vars.folderDir()
+	switch {
+	case hasVSCodeEnvRef(t.Options.Cwd):
+		setup = append(setup, "cd "+vars.shellArg(t.Options.Cwd))
+	case t.Options.Cwd != "":
+		dir = vars.dir(t.Options.Cwd)
+	}
+	params := make(executable.ParameterList, 0, len(t.Options.Env))
+	for _, key := range sortedKeys(t.Options.Env) {
+		value := t.Options.Env[key]
+		if hasVSCodeEnvRef(value) {
+			setup = append(setup, fmt.Sprintf("export %s=%s", key, vars.shellArg(value)))
+			continue
+		}
+		params = append(params, executable.Parameter{EnvKey: key, Text: vars.literal(value)})
+	}
+	if cmd != "" && len(setup) > 0 {
+		cmd = strings.Join(append(setup, cmd), " && ")
+	}
+	raw := []string{t.Command}
+	for _, a := range t.Args {
+		raw = append(raw, string(a))
+	}
+	params = append(params, vars.inputParams(strings.Join(raw, " "))...)
+
+	result := executable.ExecutableList{e}
+	switch {
+	case len(deps) == 0:
+		e.Exec = &executable.ExecExecutableType{Dir: dir, Cmd: cmd, Params: params}
+	case t.DependsOrder == "sequence":
+		execs := make(executable.SerialRefConfigList, 0, len(deps)+1)
+		for _, ref := range deps {
+			execs = append(execs, executable.SerialRefConfig{Ref: ref})
+		}
+		if cmd != "" {
+			execs = append(execs, executable.SerialRefConfig{Cmd: cmd})
+		}
+		e.Serial = &executable.SerialExecutableType{Dir: dir, Params: params, Execs: execs}
+	case cmd == "":
+		execs := make(executable.ParallelRefConfigList, 0, len(deps))
+		for _, ref := range deps {
+			execs = append(execs, executable.ParallelRefConfig{Ref: ref})
+		}
+		e.Parallel = &executable.ParallelExecutableType{Dir: dir, Params: params, Execs: execs}
+	default:
+		// The dependencies run in parallel before the task's own command.
+		first := executable.SerialRefConfig{Ref: deps[0]}
+		if len(deps) > 1 {
+			execs := make(executable.ParallelRefConfigList, 0, len(deps))
+			for _, ref := range deps {
+				execs = append(execs, executable.ParallelRefConfig{Ref: ref})
+			}
+			depsExec, depsRef := parallelDepsExecutable(
+				e, fmt.Sprintf("Run the dependencies of task %s in parallel", t.Label), dir, execs,
+			)
+			result = append(result, depsExec)
+			first = executable.SerialRefConfig{Ref: depsRef}
+		}
+		e.Serial = &executable.SerialExecutableType{
+			Dir:    dir,
+			Params: params,
+			Execs:  executable.SerialRefConfigList{first, {Cmd: cmd}},
+		}
+	}
+	return result
+}
+
+// vscodeTaskCmd returns the shell command that runs the task. Process task arguments are always quoted, while shell
+// task arguments are only quoted when they contain whitespace. Variables in quoted arguments are still expanded.
+func vscodeTaskCmd(t vscodeTask, vars *vscodeVariables) string {
+	var parts []string
+	switch t.Type {
+	case "", "shell":
+		if t.Command == "" {
+			return ""
+		}
+		parts = append(parts, vars.shell(t.Command))
+		for _, a := range t.Args {
+			if strings.ContainsAny(string(a), " \t") {
+				parts = append(parts, vars.shellArg(string(a)))
+			} else {
+				parts = append(parts, vars.shell(string(a)))
+			}
+		}
+	case "process":
+		if t.Command == "" {
+			return ""
+		}
+		parts = append(parts, vars.shellArg(t.Command))
+		for _, a := range t.Args {
+			parts = append(parts, vars.shellArg(string(a)))
+		}
+	case "npm":
+		if t.Script == "" {
+			return ""
+		}
+		parts = append(parts, "npm", "run", t.Script)
+	default:
+		return ""
+	}
+	return strings.Join(parts, " ")
+}
+
+// vscodeVariables translates VS Code's predefined and input variables.
+type vscodeVariables struct {
+	wsPath string
+	folder string
+	inputs map[string]vscodeInput
+}
+
+func (v *vscodeVariables) folderDir() executable.Directory {
+	return executable.Directory(shortenWsPath(v.wsPath, v.folder))
+}
+
+func (v *vscodeVariables) dir(path string) executable.Directory {
+	path = v.literal(path)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(v.folder, path)
+	}
+	return executable.Directory(shortenWsPath(v.wsPath, path))
+}
+
+// literal translates variables for values that aren't run by a shell (e.g. env values and directories). Env vars
+// aren't translated, since their values are only known when the task runs (see hasVSCodeEnvRef).
+func (v *vscodeVariables) literal(s string) string {
+	return vscodeVariable.ReplaceAllStringFunc(s, func(match string) string {
+		m := vscodeVariable.FindStringSubmatch(match)
+		switch m[1] {
+		case "workspaceFolder", "workspaceRoot":
+			return v.folder
+		case "workspaceFolderBasename":
+			return filepath.Base(v.folder)
+		case "pathSeparator":
+			return string(filepath.Separator)
+		}
+		return match
+	})
+}
+
+// shell translates variables for commands, referencing the environment where possible.
+func (v *vscodeVariables) shell(s string) string {
+	return vscodeVariable.ReplaceAllStringFunc(s, func(match string) string {
+		m := vscodeVariable.FindStringSubmatch(match)
+		switch m[1] {
+		case "workspaceFolder", "workspaceRoot":
+			if v.wsPath != "" && strings.HasPrefix(v.folder, v.wsPath) {
+				return "${FLOW_WORKSPACE_PATH}" + strings.TrimPrefix(v.folder, v.wsPath)
+			}
+			return v.folder
+		case "userHome":
+			return "${HOME}"
+		case "env":
+			return fmt.Sprintf("${%s}", m[2])
+		case "input":
+			if in, found := v.inputs[m[2]]; found {
+				if hasShellDefault(in) {
+					return fmt.Sprintf("${%s:-%s}", vscodeInputEnvKey(m[2]), in.Default)
+				}
+				return fmt.Sprintf("${%s}", vscodeInputEnvKey(m[2]))
+			}
+		}
+		return v.literal(match)
+	})
+}
+
+// shellArg quotes s as a single shell argument while leaving the variables in it to be expanded, e.g.
+// `--config=${workspaceFolder}/my app` becomes `--config="${FLOW_WORKSPACE_PATH}"'/my app'`.
+func (v *vscodeVariables) shellArg(s string) string {
+	if s == "" {
+		return "''"
+	}
+	var b strings.Builder
+	last := 0
+	for _, loc := range vscodeVariable.FindAllStringIndex(s, -1) {
+		if loc[0] > last {
+			b.WriteString(utils.ShellQuote(s[last:loc[0]]))
+		}
+		if value := v.shell(s[loc[0]:loc[1]]); strings.HasPrefix(value, "${") {
+			b.WriteString(`"` + value + `"`)
+		} else {
+			b.WriteString(utils.ShellQuote(value))
+		}
+		last = loc[1]
+	}
+	if last < len(s) {
+		b.WriteString(utils.ShellQuote(s[last:]))
+	}
+	return b.String()
+}
+
+// hasVSCodeEnvRef reports whether s references an env var (e.g. `${env:HOME}`).
+func hasVSCodeEnvRef(s string) bool {
+	for _, m := range vscodeVariable.FindAllStringSubmatch(s, -1) {
+		if m[1] == "env" {
+			return true
+		}
+	}
+	return false
+}
+
+// hasShellDefault reports whether an input has a default that can be used as the default value of a shell variable
+// without quoting.
+func hasShellDefault(in vscodeInput) bool {
+	return in.Default != "" && utils.ShellQuote(in.Default) == in.Default
+}
+
+// inputParams returns prompt params for the pickString and promptString inputs referenced in s.
+func (v *vscodeVariables) inputParams(s string) executable.ParameterList {
+	var params executable.ParameterList
+	seen := make(map[string]bool)
+	for _, m := range vscodeVariable.FindAllStringSubmatch(s, -1) {
+		in, found := v.inputs[m[2]]
+		if m[1] != "input" || !found || seen[in.ID] {
+			continue
+		}
+		seen[in.ID] = true
+		prompt := in.Description
+		if prompt == "" {
+			prompt = in.ID
+		}
+		switch in.Type {
+		case "pickString":
+			options := make([]string, 0, len(in.Options))
+			for _, o := range in.Options {
+				options = append(options, string(o))
+			}
+			prompt = fmt.Sprintf("%s (%s)", prompt, strings.Join(options, ", "))
+		case "promptString":
+		default:
+			continue
+		}
+		if hasShellDefault(in) {
+			prompt = fmt.Sprintf("%s [default: %s]", prompt, in.Default)
+		}
+		params = append(params, executable.Parameter{EnvKey: vscodeInputEnvKey(in.ID), Prompt: prompt})
+	}
+	return params
+}
+
+func vscodeInputEnvKey(id string) string {
+	return importArgEnvKey(invalidEnvChars.ReplaceAllString(id, "_"), "INPUT_")
+}
+
+// stripJSONC removes the comments and trailing commas that VS Code allows in its JSON files.
+func stripJSONC(data []byte) []byte {
+	out := make([]byte, 0, len(data))
+	inString, escaped := false, false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case inString:
+			out = append(out, c)
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+		case c == '"':
+			inString = true
+			out = append(out, c)
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+			out = append(out, '\n')
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			i += 2
+			for i+1 < len(data) && (data[i] != '*' || data[i+1] != '/') {
+				i++
+			}
+			i++
+		case c == '}' || c == ']':
+			// Drop a trailing comma before the closing bracket.
+			j := len(out) - 1
+			for j >= 0 && (out[j] == ' ' || out[j] == '\t' || out[j] == '\n' || out[j] == '\r') {
+				j--
+			}
+			if j >= 0 && out[j] == ',' {
+				out = append(out[:j], out[j+1:]...)
+			}
+			out = append(out, c)
+		default:
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+func (a *vscodeArg) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*a = vscodeArg(s)
+		return nil
+	}
+	var obj struct {
+		Value string `json:"value"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	*a = vscodeArg(obj.Value)
+	return nil
+}
+
+func (g *vscodeGroup) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*g = vscodeGroup(s)
+		return nil
+	}
+	var obj struct {
+		Kind string `json:"kind"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	*g = vscodeGroup(obj.Kind)
+	return nil
+}
+
+func (d *vscodeDependsOn) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*d = vscodeDependsOn{s}
+		return nil
+	}
+	var labels []string
+	if err := json.Unmarshal(data, &labels); err != nil {
+		return err
+	}
+	*d = labels
+	return nil
+}
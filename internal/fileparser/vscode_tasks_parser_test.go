@@ -0,0 +1,82 @@
+package fileparser_test
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/flowexec/flow/v2/internal/fileparser"
+	"github.com/flowexec/flow/v2/types/common"
+	"github.com/flowexec/flow/v2/types/executable"
+)
+
+var _ = Describe("ExecutablesFromVSCodeTasks", func() {
+	var (
+		execs  executable.ExecutableList
+		folder string
+	)
+
+	BeforeEach(func() {
+		var err error
+		folder, err = filepath.Abs("testdata/vscode")
+		Expect(err).NotTo(HaveOccurred())
+		execs, err = fileparser.ExecutablesFromVSCodeTasks(folder, filepath.Join(folder, ".vscode", "tasks.json"))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should create an executable for each supported task", func() {
+		Expect(execs).To(HaveLen(7))
+	})
+
+	It("should map shell tasks and their options", func() {
+		build := findExecutable(execs, executable.VerbBuild, "", "")
+		Expect(build.Description).To(Equal("Build the application"))
+		Expect(build.Tags).To(ConsistOf("generated", "vscode", "build"))
+		Expect(build.Exec.Cmd).To(Equal(
+			`export GOPATH="${HOME}"/go && go build -o ${FLOW_WORKSPACE_PATH}/bin/app ./cmd/app`,
+		))
+		Expect(build.Exec.Dir).To(Equal(executable.Directory("//src")))
+		Expect(build.Exec.Params).To(ConsistOf(
+			executable.Parameter{EnvKey: "CGO_ENABLED", Text: "0"},
+			executable.Parameter{EnvKey: "OUT", Text: filepath.Join(folder, "bin")},
+		))
+	})
+
+	It("should quote process task arguments", func() {
+		lint := findExecutable(execs, executable.VerbLint, "", "")
+		Expect(lint.Exec.Cmd).To(Equal(
+			"golangci-lint run --config \"${FLOW_WORKSPACE_PATH}\"/.golangci.yml '--out=`date` $x'",
+		))
+		Expect(lint.Visibility).To(HaveValue(Equal(executable.ExecutableVisibility(common.VisibilityInternal))))
+		Expect(findExecutable(execs, executable.VerbGenerate, "", "").Exec.Cmd).To(Equal("npm run generate"))
+	})
+
+	It("should map sequential dependencies to serial refs and inputs to prompt params", func() {
+		deploy := findExecutable(execs, executable.VerbDeploy, "", "")
+		Expect(deploy.Serial).NotTo(BeNil())
+		Expect(deploy.Serial.Execs).To(HaveLen(3))
+		Expect(deploy.Serial.Execs[0].Ref).To(Equal(executable.Ref("build")))
+		Expect(deploy.Serial.Execs[1].Ref).To(Equal(executable.Ref("lint")))
+		Expect(deploy.Serial.Execs[2].Cmd).To(Equal("./deploy.sh ${ENVIRONMENT:-staging} ${TOKEN}"))
+		Expect(deploy.Serial.Params).To(ConsistOf(
+			executable.Parameter{
+				EnvKey: "ENVIRONMENT",
+				Prompt: "Environment to deploy to (staging, production) [default: staging]",
+			},
+			executable.Parameter{EnvKey: "TOKEN", Prompt: "Deploy token"},
+		))
+	})
+
+	It("should map parallel dependencies to parallel refs", func() {
+		test := findExecutable(execs, executable.VerbTest, "", "")
+		Expect(test.Serial.Execs[0].Ref).To(Equal(executable.Ref("test deps")))
+		Expect(test.Serial.Execs[1].Cmd).To(Equal("go test ./..."))
+		Expect(findExecutable(execs, executable.VerbTest, "", "deps").Parallel.Execs).To(HaveLen(2))
+
+		check := findExecutable(execs, executable.VerbCheck, "", "")
+		Expect(check.Parallel).NotTo(BeNil())
+		Expect(check.Parallel.Execs[0].Ref).To(Equal(executable.Ref("lint")))
+		Expect(check.Parallel.Execs[1].Ref).To(Equal(executable.Ref("test")))
+	})
+})
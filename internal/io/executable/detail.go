@@ -33,6 +33,8 @@ func execTypeName(exec *executable.Executable) string {
 		return "Launch Executable"
 	case exec.Request != nil:
 		return "Request Executable"
+	case exec.Graphql != nil:
+		return "GraphQL Executable"
 	case exec.Render != nil:
 		return "Render Executable"
 	case exec.Serial != nil:
@@ -128,6 +130,8 @@ func execTypeConfig(spec *executable.Executable) string {
 		return launchExecConfig(spec.Env(), spec.Launch)
 	case spec.Request != nil:
 		return requestExecConfig(spec.Env(), spec.Request)
+	case spec.Graphql != nil:
+		return graphqlExecConfig(spec.Env(), spec.Graphql)
 	case spec.Render != nil:
 		return renderExecConfig(spec.Env(), spec.Render)
 	case spec.Serial != nil:
@@ -152,6 +156,13 @@ func shellExecConfig(e *executable.ExecutableEnvironment, s *executable.ExecExec
 	} else if s.File != "" {
 		md += fmt.Sprintf("**File:** `%s`\n\n", s.File)
 	}
+	if len(s.Prerequisites) > 0 {
+		md += "**Prerequisites**\n"
+		for i, p := range s.Prerequisites {
+			md += fmt.Sprintf("%d. %s\n", i+1, p)
+		}
+		md += "\n"
+	}
 	if container := executable.ContainerConfigMarkdown(s.Container); container != "" {
 		md += container + "\n"
 	}
@@ -191,6 +202,9 @@ func requestExecConfig(e *executable.ExecutableEnvironment, r *executable.Reques
 	if r.Body != "" {
 		md += fmt.Sprintf("**Body:**\n```\n%s\n```\n", r.Body)
 	}
+	if r.RawBody != "" {
+		md += fmt.Sprintf("**Body:**\n```\n%s\n```\n", r.RawBody)
+	}
 	if len(r.Headers) > 0 {
 		md += "\n**Headers**\n"
 		for k, v := range r.Headers {
@@ -218,6 +232,45 @@ func requestExecConfig(e *executable.ExecutableEnvironment, r *executable.Reques
 	return md
 }
 
+func graphqlExecConfig(e *executable.ExecutableEnvironment, g *executable.GraphQLExecutableType) string {
+	if g == nil {
+		return ""
+	}
+	md := "## GraphQL Configuration\n"
+	md += fmt.Sprintf("**Endpoint:** [%s](%s)\n\n", g.Endpoint, g.Endpoint)
+
+	if g.Timeout != 0 {
+		md += fmt.Sprintf("**Request Timeout:** %s\n\n", g.Timeout)
+	}
+	if g.LogResponse {
+		md += "**Log Response:** enabled\n\n"
+	}
+	if g.OperationName != "" {
+		md += fmt.Sprintf("**Operation:** %s\n\n", g.OperationName)
+	}
+	if g.Query != "" {
+		md += fmt.Sprintf("**Query:**\n```graphql\n%s\n```\n", strings.TrimSpace(g.Query))
+	}
+	if g.QueryFile != "" {
+		md += fmt.Sprintf("**Query File:** `%s`\n\n", g.QueryFile)
+	}
+	if len(g.Variables) > 0 {
+		md += "\n**Variables**\n"
+		for k, v := range g.Variables {
+			md += fmt.Sprintf("- %s: %s\n", k, v)
+		}
+		md += "\n"
+	}
+	if g.ResponseFile != nil {
+		md += fmt.Sprintf("**Response Saved To:** %s\n\n", g.ResponseFile.Filename)
+	}
+	if g.TransformResponse != "" {
+		md += fmt.Sprintf("**Transformation Expression:**\n```\n%s\n```\n", g.TransformResponse)
+	}
+	md += envTable(e)
+	return md
+}
+
 func renderExecConfig(e *executable.ExecutableEnvironment, r *executable.RenderExecutableType) string {
 	if r == nil {
 		return ""
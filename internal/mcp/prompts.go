@@ -100,7 +100,7 @@ func generateExecutablePrompt(_ context.Context, request mcp.GetPromptRequest) (
 Please generate a complete Flow executable configuration that:
 
 1. **Determines the Best Approach**:
-   - Choose the most appropriate executable type (exec, serial, parallel, request, launch)
+   - Choose the most appropriate executable type (exec, serial, parallel, request, graphql, launch)
    - Select a suitable verb if none was provided
    - Design the proper parameter and argument structure
 
@@ -0,0 +1,222 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/flowexec/flow/v2/internal/runner"
+	"github.com/flowexec/flow/v2/internal/runner/engine"
+	"github.com/flowexec/flow/v2/internal/runner/request"
+	"github.com/flowexec/flow/v2/internal/utils"
+	"github.com/flowexec/flow/v2/internal/utils/env"
+	"github.com/flowexec/flow/v2/pkg/context"
+	"github.com/flowexec/flow/v2/types/executable"
+)
+
+const jsonContentType = "application/json"
+
+// variableDefinitionPattern matches the variable definitions of a GraphQL document (`$first: Int`, `$ids: [ID!]`),
+// capturing the variable name and the start of its type.
+var variableDefinitionPattern = regexp.MustCompile(`\$([_A-Za-z][_0-9A-Za-z]*)\s*:\s*(\[|[_A-Za-z][_0-9A-Za-z]*)`)
+
+type graphqlRunner struct{}
+
+func NewRunner() runner.Runner {
+	return &graphqlRunner{}
+}
+
+func (r *graphqlRunner) Name() string {
+	return "graphql"
+}
+
+func (r *graphqlRunner) IsCompatible(executable *executable.Executable) bool {
+	if executable == nil || executable.Graphql == nil {
+		return false
+	}
+	return true
+}
+
+// response is the data that response expressions are evaluated over.
+type response struct {
+	Status  string      `expr:"status"`
+	Code    int         `expr:"code"`
+	Body    string      `expr:"body"`
+	Headers http.Header `expr:"headers"`
+	Data    any         `expr:"data"`
+}
+
+type graphqlError struct {
+	Message string `json:"message"`
+	Path    []any  `json:"path"`
+}
+
+func (r *graphqlRunner) Exec(
+	ctx *context.Context,
+	e *executable.Executable,
+	_ engine.Engine,
+	inputEnv map[string]string,
+	inputArgs []string,
+) error {
+	spec := e.Graphql
+	if err := spec.Validate(); err != nil {
+		return err
+	}
+	envMap, err := env.BuildEnvMap(
+		ctx.Config.CurrentVaultName(), e.Env(), inputArgs, inputEnv, env.DefaultEnv(ctx, e),
+	)
+	if err != nil {
+		return errors.Wrap(err, "unable to set parameters to env")
+	}
+	if err := request.AddCapturedValues(ctx, envMap); err != nil {
+		return err
+	}
+
+	body, err := requestBody(e, envMap)
+	if err != nil {
+		return err
+	}
+	reqExec := requestExecutable(e)
+	resp, err := request.SendWithBody(ctx, reqExec, envMap, body, jsonContentType)
+	if err != nil {
+		return err
+	}
+
+	var result struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []graphqlError  `json:"errors"`
+	}
+	if err := json.Unmarshal([]byte(resp.Body), &result); err != nil {
+		return errors.Wrap(err, "unable to decode graphql response")
+	}
+	if len(result.Errors) > 0 {
+		return fmt.Errorf("graphql request returned errors: %s", errorMessages(result.Errors))
+	}
+	var data any
+	if len(result.Data) > 0 {
+		if err := json.Unmarshal(result.Data, &data); err != nil {
+			return errors.Wrap(err, "unable to decode graphql response data")
+		}
+	}
+
+	dataResp := *resp
+	dataResp.Body = string(result.Data)
+	if dataResp.Body == "" {
+		dataResp.Body = "null"
+	}
+	return request.HandleResponse(ctx, reqExec, envMap, &dataResp, &response{
+		Status:  resp.Status,
+		Code:    resp.Code,
+		Body:    dataResp.Body,
+		Headers: resp.Headers,
+		Data:    data,
+	})
+}
+
+// requestBody returns the JSON body of the GraphQL request. Environment variables are expanded in the variables but
+// not in the query, where `$` starts a GraphQL variable.
+func requestBody(e *executable.Executable, envMap map[string]string) (string, error) {
+	spec := e.Graphql
+	query := spec.Query
+	if spec.QueryFile != "" {
+		data, err := os.ReadFile(filepath.Clean(queryFilePath(e, envMap)))
+		if err != nil {
+			return "", errors.Wrap(err, "unable to read graphql query file")
+		}
+		query = string(data)
+	}
+
+	payload := map[string]any{"query": query}
+	if spec.OperationName != "" {
+		payload["operationName"] = spec.OperationName
+	}
+	if len(spec.Variables) > 0 {
+		jsonVariables := jsonVariableNames(query)
+		variables := make(map[string]any, len(spec.Variables))
+		for key, value := range spec.Variables {
+			expanded := os.Expand(value, func(envVar string) string { return envMap[envVar] })
+			if jsonVariables[key] && json.Valid([]byte(expanded)) {
+				variables[key] = json.RawMessage(expanded)
+			} else {
+				variables[key] = expanded
+			}
+		}
+		payload["variables"] = variables
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to encode graphql request")
+	}
+	return string(body), nil
+}
+
+// jsonVariableNames returns the variables that the query declares with a type other than String or ID. Their values
+// are sent as JSON, while the values of other variables are always sent as strings.
+func jsonVariableNames(query string) map[string]bool {
+	names := make(map[string]bool)
+	for _, match := range variableDefinitionPattern.FindAllStringSubmatch(query, -1) {
+		if match[2] != "String" && match[2] != "ID" {
+			names[match[1]] = true
+		}
+	}
+	return names
+}
+
+// requestExecutable returns a copy of the executable with its GraphQL spec converted to the POST request that sends
+// it, so that it's sent and handled by the request runner.
+func requestExecutable(e *executable.Executable) *executable.Executable {
+	spec := e.Graphql
+	headers := map[string]string{"Accept": jsonContentType}
+	for key, value := range spec.Headers {
+		headers[key] = value
+	}
+	reqExec := *e
+	reqExec.Graphql = nil
+	reqExec.Request = &executable.RequestExecutableType{
+		Params:            spec.Params,
+		Args:              spec.Args,
+		Method:            executable.RequestExecutableTypeMethodPOST,
+		URL:               spec.Endpoint,
+		Headers:           headers,
+		Auth:              spec.Auth,
+		Timeout:           spec.Timeout,
+		Tls:               (*executable.RequestExecutableTypeTls)(spec.Tls),
+		Proxy:             spec.Proxy,
+		ResponseFile:      spec.ResponseFile,
+		TransformResponse: spec.TransformResponse,
+		LogResponse:       spec.LogResponse,
+		Assert:            spec.Assert,
+		Capture:           executable.RequestExecutableTypeCapture(spec.Capture),
+	}
+	return &reqExec
+}
+
+func queryFilePath(e *executable.Executable, envMap map[string]string) string {
+	path := e.Graphql.QueryFile
+	if rel, ok := strings.CutPrefix(path, "//"); ok && e.WorkspacePath() != "" {
+		path = filepath.Join(e.WorkspacePath(), filepath.FromSlash(rel))
+	}
+	return utils.ExpandPath(path, filepath.Dir(e.FlowFilePath()), envMap)
+}
+
+func errorMessages(errs []graphqlError) string {
+	msgs := make([]string, 0, len(errs))
+	for _, err := range errs {
+		msg := err.Message
+		if len(err.Path) > 0 {
+			path := make([]string, 0, len(err.Path))
+			for _, p := range err.Path {
+				path = append(path, fmt.Sprint(p))
+			}
+			msg += fmt.Sprintf(" (at %s)", strings.Join(path, "."))
+		}
+		msgs = append(msgs, msg)
+	}
+	return strings.Join(msgs, "; ")
+}
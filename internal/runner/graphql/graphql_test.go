@@ -0,0 +1,150 @@
+package graphql_test
+
+import (
+	stdCtx "context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/flowexec/flow/v2/internal/runner"
+	"github.com/flowexec/flow/v2/internal/runner/engine/mocks"
+	"github.com/flowexec/flow/v2/internal/runner/graphql"
+	testUtils "github.com/flowexec/flow/v2/tests/utils"
+	"github.com/flowexec/flow/v2/types/executable"
+)
+
+func TestGraphQL(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "GraphQL Suite")
+}
+
+var _ = Describe("GraphQL Runner", func() {
+	var (
+		graphqlRnr runner.Runner
+		ctx        *testUtils.ContextWithMocks
+		mockEngine *mocks.MockEngine
+	)
+
+	BeforeEach(func() {
+		ctx = testUtils.NewContextWithMocks(stdCtx.Background(), GinkgoTB())
+		graphqlRnr = graphql.NewRunner()
+		mockEngine = mocks.NewMockEngine(gomock.NewController(GinkgoT()))
+	})
+
+	Context("Name", func() {
+		It("should return the correct runner name", func() {
+			Expect(graphqlRnr.Name()).To(Equal("graphql"))
+		})
+	})
+
+	Context("IsCompatible", func() {
+		It("should return false when executable is nil", func() {
+			Expect(graphqlRnr.IsCompatible(nil)).To(BeFalse())
+		})
+
+		It("should return true when executable type is graphql", func() {
+			Expect(graphqlRnr.IsCompatible(&executable.Executable{
+				Graphql: &executable.GraphQLExecutableType{},
+			})).To(BeTrue())
+		})
+	})
+
+	Describe("Exec", func() {
+		var (
+			server  *httptest.Server
+			gotReq  *http.Request
+			payload map[string]any
+			respond string
+			flowDir string
+		)
+
+		BeforeEach(func() {
+			respond = `{"data": {"viewer": {"login": "flow"}}}`
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotReq = r
+				payload = nil
+				_ = json.NewDecoder(r.Body).Decode(&payload)
+				_, _ = w.Write([]byte(respond))
+			}))
+			DeferCleanup(server.Close)
+			flowDir = GinkgoT().TempDir()
+		})
+
+		exec := func(spec *executable.GraphQLExecutableType, env map[string]string) error {
+			spec.Endpoint = server.URL + "/graphql"
+			e := &executable.Executable{Graphql: spec}
+			e.SetContext(ctx.Ctx.CurrentWorkspace.AssignedName(), flowDir, "", filepath.Join(flowDir, "api.flow"))
+			return graphqlRnr.Exec(ctx.Ctx, e, mockEngine, env, nil)
+		}
+
+		It("should send the query and variables and transform the response data", func() {
+			ctx.Logger.EXPECT().Info(gomock.Any(), gomock.Any(), "flow").Times(1)
+			err := exec(&executable.GraphQLExecutableType{
+				Query:     `query Repo($name: String!, $first: Int) { viewer { login } }`,
+				Variables: map[string]string{"name": "$REPO", "first": "$LIMIT"},
+				Headers:   map[string]string{"X-Team": "$TEAM"},
+				Auth: &executable.RequestAuth{
+					Bearer: &executable.RequestBearerAuth{Token: "abc"},
+				},
+				TransformResponse: "data.viewer.login",
+				LogResponse:       true,
+			}, map[string]string{"REPO": "flow", "LIMIT": "10", "TEAM": "core"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(gotReq.Method).To(Equal(http.MethodPost))
+			Expect(gotReq.Header.Get("Content-Type")).To(Equal("application/json"))
+			Expect(gotReq.Header.Get("Authorization")).To(Equal("Bearer abc"))
+			Expect(gotReq.Header.Get("X-Team")).To(Equal("core"))
+			Expect(payload["query"]).To(ContainSubstring("$name: String!"))
+			Expect(payload["variables"]).To(Equal(map[string]any{"name": "flow", "first": float64(10)}))
+		})
+
+		It("should only send variables as JSON when the query declares a non-string type", func() {
+			ctx.Logger.EXPECT().Infof(gomock.Any(), gomock.Any()).Times(1)
+			Expect(exec(&executable.GraphQLExecutableType{
+				Query: `query Search($term: String!, $id: ID, $filter: Filter, $tags: [String!]) { search { id } }`,
+				Variables: map[string]string{
+					"term": "$TERM", "id": "123", "filter": `{"open": true}`, "tags": `["a"]`, "other": "null",
+				},
+			}, map[string]string{"TERM": `{"injected": true}`})).To(Succeed())
+			Expect(payload["variables"]).To(Equal(map[string]any{
+				"term":   `{"injected": true}`,
+				"id":     "123",
+				"filter": map[string]any{"open": true},
+				"tags":   []any{"a"},
+				"other":  "null",
+			}))
+		})
+
+		It("should read the query from a file relative to the flow file", func() {
+			Expect(os.WriteFile(filepath.Join(flowDir, "viewer.graphql"), []byte("query Viewer { viewer { login } }"), 0600)).
+				To(Succeed())
+			ctx.Logger.EXPECT().Info(gomock.Any(), gomock.Any(), `{"viewer": {"login": "flow"}}`).Times(1)
+			Expect(exec(&executable.GraphQLExecutableType{
+				QueryFile:     "viewer.graphql",
+				OperationName: "Viewer",
+				LogResponse:   true,
+			}, nil)).To(Succeed())
+			Expect(payload["query"]).To(Equal("query Viewer { viewer { login } }"))
+			Expect(payload["operationName"]).To(Equal("Viewer"))
+		})
+
+		It("should fail when the response contains errors", func() {
+			respond = `{"data": null, "errors": [{"message": "not found", "path": ["repository", 0]}, {"message": "denied"}]}`
+			err := exec(&executable.GraphQLExecutableType{Query: "{ repository { name } }"}, nil)
+			Expect(err).To(MatchError("graphql request returned errors: not found (at repository.0); denied"))
+		})
+
+		It("should fail when both query and queryFile are set", func() {
+			err := exec(&executable.GraphQLExecutableType{Query: "{ a }", QueryFile: "a.graphql"}, nil)
+			Expect(err).To(MatchError(ContainSubstring("must define only one graphql query")))
+		})
+	})
+})
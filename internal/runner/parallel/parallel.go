@@ -200,6 +200,10 @@ func handleExec(
 			if exec.Request.ResponseFile != nil && parallelSpec.Dir != "" && exec.Request.ResponseFile.Dir == "" {
 				exec.Request.ResponseFile.Dir = parallelSpec.Dir
 			}
+		case exec.Graphql != nil:
+			if exec.Graphql.ResponseFile != nil && parallelSpec.Dir != "" && exec.Graphql.ResponseFile.Dir == "" {
+				exec.Graphql.ResponseFile.Dir = parallelSpec.Dir
+			}
 		case exec.Render != nil:
 			if parallelSpec.Dir != "" && exec.Render.Dir == "" {
 				exec.Render.Dir = parallelSpec.Dir
@@ -0,0 +1,94 @@
+package request
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/jahvon/expression"
+	"github.com/pkg/errors"
+
+	"github.com/flowexec/flow/v2/internal/services/rest"
+	"github.com/flowexec/flow/v2/pkg/context"
+	"github.com/flowexec/flow/v2/pkg/logger"
+	"github.com/flowexec/flow/v2/pkg/store"
+)
+
+// maxErrorBodyLen is the number of bytes of a response body that are included in errors.
+const maxErrorBodyLen = 512
+
+// checkAssertions returns an error for the first assertion that isn't true for the response data.
+func checkAssertions(assertions []string, data expression.Data, resp *rest.Response) error {
+	for _, assertion := range assertions {
+		truthy, err := expression.IsTruthy(assertion, data)
+		if err != nil {
+			return errors.Wrapf(err, "unable to evaluate assertion `%s`", assertion)
+		}
+		if !truthy {
+			return fmt.Errorf("assertion `%s` failed (%s): %s", assertion, resp.Status, truncateBody(resp.Body))
+		}
+	}
+	return nil
+}
+
+// captureValues saves the results of the capture expressions to the data store of the current process.
+func captureValues(ctx *context.Context, capture map[string]string, data expression.Data) error {
+	if len(capture) == 0 {
+		return nil
+	}
+	if ctx.DataStore == nil {
+		return errors.New("unable to capture response values without a data store")
+	}
+	bucket := store.EnvironmentBucket()
+	for _, key := range sortedKeys(capture) {
+		value, err := expression.EvaluateString(capture[key], data)
+		if err != nil {
+			return errors.Wrapf(err, "unable to evaluate capture expression for %s", key)
+		}
+		if err := ctx.DataStore.SetProcessVar(bucket, key, value); err != nil {
+			return errors.Wrapf(err, "unable to save captured value %s", key)
+		}
+		logger.Log().Debugf("captured %s from response", key)
+	}
+	return nil
+}
+
+// AddCapturedValues adds the values in the data store of the current process, such as those captured from the
+// responses of earlier requests, to envMap. Values that are already set are kept.
+func AddCapturedValues(ctx *context.Context, envMap map[string]string) error {
+	if ctx.DataStore == nil {
+		return nil
+	}
+	values, err := ctx.DataStore.GetAllProcessVars(store.EnvironmentBucket())
+	if err != nil {
+		return errors.Wrap(err, "unable to read captured values")
+	}
+	for key, value := range values {
+		if _, found := envMap[key]; !found {
+			envMap[key] = value
+		}
+	}
+	return nil
+}
+
+// statusCodeError adds the response body to an error for an unexpected status code.
+func statusCodeError(err error) error {
+	var statusErr *rest.StatusCodeError
+	if !errors.As(err, &statusErr) {
+		return err
+	}
+	return fmt.Errorf("%w: %s", err, truncateBody(statusErr.Response.Body))
+}
+
+func truncateBody(body string) string {
+	if body == "" {
+		return "<empty body>"
+	}
+	if len(body) <= maxErrorBodyLen {
+		return body
+	}
+	truncated := body[:maxErrorBodyLen]
+	for !utf8.ValidString(truncated) {
+		truncated = truncated[:len(truncated)-1]
+	}
+	return fmt.Sprintf("%s... (%d bytes truncated)", truncated, len(body)-len(truncated))
+}
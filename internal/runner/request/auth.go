@@ -0,0 +1,228 @@
+package request
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/flowexec/flow/v2/internal/services/rest"
+	"github.com/flowexec/flow/v2/internal/utils/env"
+	"github.com/flowexec/flow/v2/pkg/context"
+	"github.com/flowexec/flow/v2/pkg/logger"
+	"github.com/flowexec/flow/v2/types/executable"
+)
+
+const (
+	oauth2CacheKeyPrefix = "oauth2-token:"
+	// oauth2ExpiryMargin is how long before its expiry a cached token is replaced, so that it doesn't expire while
+	// the request is in flight.
+	oauth2ExpiryMargin = 30 * time.Second
+)
+
+// oauth2Token is an access token as it's cached in the data store.
+type oauth2Token struct {
+	AccessToken string    `json:"accessToken"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+}
+
+// credentialResolver returns the value of a credential that's either set as text, with env vars expanded, or read
+// from a vault secret.
+type credentialResolver func(value, secretRef string) (string, error)
+
+// applyAuth adds the credentials of the request's auth method to its headers or URL. The credentials are never
+// logged.
+func applyAuth(
+	ctx *context.Context,
+	auth *executable.RequestAuth,
+	envMap map[string]string,
+	req *rest.Request,
+) error {
+	if auth == nil {
+		return nil
+	}
+	if err := auth.Validate(); err != nil {
+		return err
+	}
+	resolve := func(value, secretRef string) (string, error) {
+		if secretRef != "" {
+			return env.ResolveSecretValue(ctx.Config.CurrentVaultName(), secretRef)
+		}
+		return expandEnvVars(envMap, value), nil
+	}
+
+	switch {
+	case auth.Basic != nil:
+		username, err := resolve(auth.Basic.Username, auth.Basic.UsernameSecretRef)
+		if err != nil {
+			return errors.Wrap(err, "unable to resolve basic auth username")
+		}
+		password, err := resolve(auth.Basic.Password, auth.Basic.PasswordSecretRef)
+		if err != nil {
+			return errors.Wrap(err, "unable to resolve basic auth password")
+		}
+		req.Headers["Authorization"] = "Basic " + basicCredentials(username, password)
+	case auth.Bearer != nil:
+		token, err := resolve(auth.Bearer.Token, auth.Bearer.TokenSecretRef)
+		if err != nil {
+			return errors.Wrap(err, "unable to resolve bearer token")
+		}
+		req.Headers["Authorization"] = "Bearer " + token
+	case auth.ApiKey != nil:
+		key, err := resolve(auth.ApiKey.Value, auth.ApiKey.SecretRef)
+		if err != nil {
+			return errors.Wrap(err, "unable to resolve api key")
+		}
+		if auth.ApiKey.In != executable.RequestAPIKeyAuthInQuery {
+			req.Headers[auth.ApiKey.Name] = key
+			break
+		}
+		reqURL, err := url.Parse(req.URL)
+		if err != nil {
+			return errors.Wrap(err, "unable to parse request URL")
+		}
+		query := reqURL.Query()
+		query.Set(auth.ApiKey.Name, key)
+		reqURL.RawQuery = query.Encode()
+		req.URL = reqURL.String()
+		if fixtures := req.Client.Fixtures; fixtures != nil {
+			redacted := *fixtures
+			redacted.RedactQuery = append(slices.Clone(fixtures.RedactQuery), auth.ApiKey.Name)
+			req.Client.Fixtures = &redacted
+		}
+	case auth.Oauth2 != nil:
+		if fixtures := req.Client.Fixtures; fixtures != nil && fixtures.Mode == rest.FixtureModeReplay {
+			// Replayed responses don't depend on the credentials, so no token is requested.
+			break
+		}
+		token, err := oauth2AccessToken(ctx, auth.Oauth2, resolve, envMap, req)
+		if err != nil {
+			return err
+		}
+		req.Headers["Authorization"] = "Bearer " + token
+	}
+	return nil
+}
+
+// oauth2AccessToken returns an access token from the OAuth2 client credentials flow. The token request is sent with
+// the timeout and client options of the request, but is never recorded as a fixture since its body and response are
+// credentials. Tokens are cached in the data store until they expire.
+func oauth2AccessToken(
+	ctx *context.Context,
+	spec *executable.RequestOAuth2Auth,
+	resolve credentialResolver,
+	envMap map[string]string,
+	req *rest.Request,
+) (string, error) {
+	clientID, err := resolve(spec.ClientId, spec.ClientIdSecretRef)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to resolve oauth2 client ID")
+	}
+	clientSecret, err := resolve(spec.ClientSecret, spec.ClientSecretRef)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to resolve oauth2 client secret")
+	}
+	tokenURL := expandEnvVars(envMap, spec.TokenURL)
+
+	cacheKey := oauth2CacheKey(tokenURL, clientID, spec.Scopes)
+	if token, found := cachedOAuth2Token(ctx, cacheKey); found {
+		logger.Log().Debugf("using cached oauth2 token for %s", tokenURL)
+		return token, nil
+	}
+
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(spec.Scopes) > 0 {
+		form.Set("scope", strings.Join(spec.Scopes, " "))
+	}
+	headers := map[string]string{
+		"Content-Type": "application/x-www-form-urlencoded",
+		"Accept":       "application/json",
+	}
+	if spec.CredentialsInBody {
+		form.Set("client_id", clientID)
+		form.Set("client_secret", clientSecret)
+	} else {
+		headers["Authorization"] = "Basic " + basicCredentials(url.QueryEscape(clientID), url.QueryEscape(clientSecret))
+	}
+
+	client := req.Client
+	client.Fixtures = nil
+	resp, err := rest.SendRequest(&rest.Request{
+		URL:     tokenURL,
+		Method:  http.MethodPost,
+		Headers: headers,
+		Body:    form.Encode(),
+		Timeout: req.Timeout,
+		Client:  client,
+	}, nil)
+	if err != nil {
+		return "", errors.Wrapf(err, "oauth2 token request to %s failed", tokenURL)
+	}
+
+	var tokenResp struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.Unmarshal([]byte(resp.Body), &tokenResp); err != nil {
+		return "", errors.Wrap(err, "unable to decode oauth2 token response")
+	}
+	if tokenResp.AccessToken == "" {
+		return "", fmt.Errorf("oauth2 token response from %s has no access_token", tokenURL)
+	}
+	if tokenResp.ExpiresIn > 0 {
+		cacheOAuth2Token(ctx, cacheKey, oauth2Token{
+			AccessToken: tokenResp.AccessToken,
+			ExpiresAt:   time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second),
+		})
+	}
+	return tokenResp.AccessToken, nil
+}
+
+func cachedOAuth2Token(ctx *context.Context, key string) (string, bool) {
+	if ctx.DataStore == nil {
+		return "", false
+	}
+	data, err := ctx.DataStore.GetCacheEntry(key)
+	if err != nil || len(data) == 0 {
+		return "", false
+	}
+	var token oauth2Token
+	if err := json.Unmarshal(data, &token); err != nil {
+		return "", false
+	}
+	if time.Until(token.ExpiresAt) < oauth2ExpiryMargin {
+		return "", false
+	}
+	return token.AccessToken, true
+}
+
+func cacheOAuth2Token(ctx *context.Context, key string, token oauth2Token) {
+	if ctx.DataStore == nil {
+		return
+	}
+	data, err := json.Marshal(token)
+	if err != nil {
+		return
+	}
+	if err := ctx.DataStore.SetCacheEntry(key, data); err != nil {
+		logger.Log().Debug("unable to cache oauth2 token", "err", err)
+	}
+}
+
+// oauth2CacheKey identifies the tokens of a client without including its ID in the data store.
+func oauth2CacheKey(tokenURL, clientID string, scopes []string) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{tokenURL, clientID, strings.Join(scopes, " ")}, "\n")))
+	return oauth2CacheKeyPrefix + hex.EncodeToString(sum[:])
+}
+
+func basicCredentials(username, password string) string {
+	return base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+}
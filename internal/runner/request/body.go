@@ -0,0 +1,168 @@
+package request
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/jahvon/expression"
+	"github.com/pkg/errors"
+
+	"github.com/flowexec/flow/v2/internal/utils"
+	"github.com/flowexec/flow/v2/types/executable"
+)
+
+const (
+	contentTypeHeader = "Content-Type"
+	jsonContentType   = "application/json"
+	formContentType   = "application/x-www-form-urlencoded"
+	binaryContentType = "application/octet-stream"
+)
+
+// requestBody returns the body of a request, from its `body`, `bodyFile`, `form` or `multipart` option, along with
+// the content type that's inferred for it. The content type is empty when it can't be inferred.
+func requestBody(e *executable.Executable, envMap map[string]string) (string, string, error) {
+	spec := e.Request
+	switch {
+	case spec.BodyFile != "" && expandEnvVars(envMap, spec.BodyFile) != "":
+		// The path is expanded first so that relative paths from args resolve like literal ones.
+		path := requestFilePath(e, expandEnvVars(envMap, spec.BodyFile), envMap)
+		data, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return "", "", errors.Wrap(err, "unable to read request body file")
+		}
+		contentType := mime.TypeByExtension(filepath.Ext(path))
+		if contentType == "" {
+			contentType = binaryContentType
+		}
+		return string(data), contentType, nil
+	case len(spec.Form) > 0:
+		form := make(url.Values, len(spec.Form))
+		for key, value := range spec.Form {
+			form.Set(key, expandEnvVars(envMap, value))
+		}
+		return form.Encode(), formContentType, nil
+	case spec.Multipart != nil:
+		return multipartBody(e, spec.Multipart, envMap)
+	case spec.RawBody != "":
+		body := expandEnvVars(envMap, spec.RawBody)
+		if json.Valid([]byte(body)) {
+			return body, jsonContentType, nil
+		}
+		return body, "", nil
+	case spec.Body != "":
+		body := expandEnvVars(envMap, spec.Body)
+		if body == "" {
+			return "", "", nil
+		}
+		// JSON bodies are sent as-is; evaluating them as an expression would turn objects into Go map strings.
+		if json.Valid([]byte(body)) {
+			return body, jsonContentType, nil
+		}
+		evaluated, err := expression.EvaluateString(body, map[string]interface{}{"env": envMap})
+		if err != nil {
+			return "", "", errors.Wrap(err, "unable to evaluate request body expression")
+		}
+		if json.Valid([]byte(evaluated)) {
+			return evaluated, jsonContentType, nil
+		}
+		return evaluated, "", nil
+	}
+	return "", "", nil
+}
+
+func multipartBody(
+	e *executable.Executable,
+	spec *executable.RequestMultipart,
+	envMap map[string]string,
+) (string, string, error) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	for _, key := range sortedKeys(spec.Fields) {
+		if err := w.WriteField(key, expandEnvVars(envMap, spec.Fields[key])); err != nil {
+			return "", "", errors.Wrap(err, "unable to write multipart field")
+		}
+	}
+	for _, key := range sortedKeys(spec.Files) {
+		if err := writeMultipartFile(w, key, requestFilePath(e, spec.Files[key], envMap)); err != nil {
+			return "", "", err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return "", "", errors.Wrap(err, "unable to write multipart body")
+	}
+	return buf.String(), w.FormDataContentType(), nil
+}
+
+func writeMultipartFile(w *multipart.Writer, field, path string) error {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return errors.Wrapf(err, "unable to open multipart file for field %s", field)
+	}
+	defer f.Close()
+	part, err := w.CreateFormFile(field, filepath.Base(path))
+	if err != nil {
+		return errors.Wrap(err, "unable to write multipart file")
+	}
+	if _, err := io.Copy(part, f); err != nil {
+		return errors.Wrapf(err, "unable to read multipart file for field %s", field)
+	}
+	return nil
+}
+
+// withQuery adds the query parameters to a URL, URL-encoding their values.
+func withQuery(rawURL string, query map[string]string, envMap map[string]string) (string, error) {
+	if len(query) == 0 {
+		return rawURL, nil
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to parse request URL")
+	}
+	values := u.Query()
+	for key, value := range query {
+		expanded := expandEnvVars(envMap, value)
+		if expanded == "" && value != "" {
+			// Optional parameters are omitted when the env vars that set them are empty.
+			continue
+		}
+		values.Set(key, expanded)
+	}
+	u.RawQuery = values.Encode()
+	return u.String(), nil
+}
+
+// requestFilePath returns the absolute path of a file used by a request. Paths are relative to the executable's flow
+// file unless they start with `//` (the workspace root).
+func requestFilePath(e *executable.Executable, path string, envMap map[string]string) string {
+	if rel, ok := strings.CutPrefix(path, "//"); ok && e.WorkspacePath() != "" {
+		path = filepath.Join(e.WorkspacePath(), filepath.FromSlash(rel))
+	}
+	return utils.ExpandPath(path, filepath.Dir(e.FlowFilePath()), envMap)
+}
+
+// hasHeader reports whether a header is set, ignoring the case of its name.
+func hasHeader(headers map[string]string, name string) bool {
+	for key := range headers {
+		if strings.EqualFold(key, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
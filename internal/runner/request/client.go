@@ -0,0 +1,219 @@
+package request
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/flowexec/flow/v2/internal/services/rest"
+	"github.com/flowexec/flow/v2/internal/utils"
+	"github.com/flowexec/flow/v2/internal/utils/env"
+	"github.com/flowexec/flow/v2/pkg/context"
+	"github.com/flowexec/flow/v2/pkg/logger"
+	"github.com/flowexec/flow/v2/types/common"
+	"github.com/flowexec/flow/v2/types/executable"
+	"github.com/flowexec/flow/v2/types/workspace"
+)
+
+// tlsSource is the TLS configuration of a request, with its file paths made absolute.
+type tlsSource struct {
+	caFile             string
+	certFile           string
+	certSecretRef      string
+	keyFile            string
+	keySecretRef       string
+	insecureSkipVerify bool
+}
+
+// clientOptions returns the HTTP client options of a request. Options that the request doesn't set default to the
+// `requestDefaults` of its workspace.
+func clientOptions(
+	ctx *context.Context,
+	e *executable.Executable,
+	envMap map[string]string,
+) (rest.ClientOptions, error) {
+	spec := e.Request
+	defaults := &common.RequestClient{}
+	var src tlsSource
+	if ws := executableWorkspace(ctx, e); ws != nil && ws.RequestDefaults != nil {
+		defaults = (*common.RequestClient)(ws.RequestDefaults)
+		src = workspaceTLSSource(ws, defaults.Tls, envMap)
+	}
+	if spec.Tls != nil {
+		src.override(e, (*common.RequestTLS)(spec.Tls), envMap)
+	}
+
+	var opts rest.ClientOptions
+	tlsConfig, err := buildTLSConfig(ctx, src)
+	if err != nil {
+		return opts, err
+	}
+	if tlsConfig != nil && tlsConfig.InsecureSkipVerify {
+		logger.Log().Warnf("TLS certificate verification is disabled for requests to %s", spec.URL)
+	}
+	opts.TLSConfig = tlsConfig
+
+	proxy := firstNonEmpty(spec.Proxy, defaults.Proxy)
+	if proxy != "" {
+		opts.ProxyURL, err = url.Parse(expandEnvVars(envMap, proxy))
+		if err != nil {
+			return opts, errors.Wrap(err, "unable to parse proxy URL")
+		}
+	}
+
+	followRedirects := true
+	switch {
+	case spec.FollowRedirects != nil:
+		followRedirects = *spec.FollowRedirects
+	case defaults.FollowRedirects != nil:
+		followRedirects = *defaults.FollowRedirects
+	}
+	maxRedirects := spec.MaxRedirects
+	if maxRedirects == nil {
+		maxRedirects = defaults.MaxRedirects
+	}
+	opts.DisableRedirects = !followRedirects || (maxRedirects != nil && *maxRedirects == 0)
+	if maxRedirects != nil {
+		opts.MaxRedirects = *maxRedirects
+	}
+	opts.Fixtures, err = fixturesFromEnv()
+	return opts, err
+}
+
+// executableWorkspace returns the config of the executable's workspace, or nil when it can't be found.
+func executableWorkspace(ctx *context.Context, e *executable.Executable) *workspace.Workspace {
+	if e.Workspace() == "" {
+		return nil
+	}
+	if ctx.CurrentWorkspace != nil && ctx.CurrentWorkspace.AssignedName() == e.Workspace() {
+		return ctx.CurrentWorkspace
+	}
+	if ctx.WorkspacesCache == nil {
+		return nil
+	}
+	wsList, err := ctx.WorkspacesCache.GetWorkspaceConfigList()
+	if err != nil {
+		logger.Log().Debugf("unable to load workspaces for request defaults: %v", err)
+		return nil
+	}
+	return wsList.FindByName(e.Workspace())
+}
+
+// workspaceTLSSource returns the TLS configuration of a workspace's request defaults, with paths relative to the
+// workspace root.
+func workspaceTLSSource(ws *workspace.Workspace, spec *common.RequestTLS, envMap map[string]string) tlsSource {
+	if spec == nil {
+		return tlsSource{}
+	}
+	path := func(p string) string {
+		if p == "" {
+			return ""
+		}
+		return utils.ExpandPath(strings.TrimPrefix(p, "//"), ws.Location(), envMap)
+	}
+	return tlsSource{
+		caFile:             path(spec.CaFile),
+		certFile:           path(spec.CertFile),
+		certSecretRef:      spec.CertSecretRef,
+		keyFile:            path(spec.KeyFile),
+		keySecretRef:       spec.KeySecretRef,
+		insecureSkipVerify: spec.InsecureSkipVerify,
+	}
+}
+
+// override replaces the settings of the source with the ones that are set by the executable. A certificate or key
+// that's set replaces both the file and secret of the defaults.
+func (s *tlsSource) override(e *executable.Executable, spec *common.RequestTLS, envMap map[string]string) {
+	if spec.CaFile != "" {
+		s.caFile = requestFilePath(e, spec.CaFile, envMap)
+	}
+	if spec.CertFile != "" || spec.CertSecretRef != "" {
+		s.certFile, s.certSecretRef = "", spec.CertSecretRef
+		if spec.CertFile != "" {
+			s.certFile = requestFilePath(e, spec.CertFile, envMap)
+		}
+	}
+	if spec.KeyFile != "" || spec.KeySecretRef != "" {
+		s.keyFile, s.keySecretRef = "", spec.KeySecretRef
+		if spec.KeyFile != "" {
+			s.keyFile = requestFilePath(e, spec.KeyFile, envMap)
+		}
+	}
+	if spec.InsecureSkipVerify {
+		s.insecureSkipVerify = true
+	}
+}
+
+// buildTLSConfig returns the TLS config of a request, or nil when it uses the defaults.
+func buildTLSConfig(ctx *context.Context, src tlsSource) (*tls.Config, error) {
+	if src == (tlsSource{}) {
+		return nil, nil
+	}
+	cfg := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: src.insecureSkipVerify, //nolint:gosec // opt-in for testing, a warning is logged
+	}
+
+	if src.caFile != "" {
+		pem, err := os.ReadFile(filepath.Clean(src.caFile))
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to read TLS CA file")
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no PEM certificates found in TLS CA file %s", src.caFile)
+		}
+		cfg.RootCAs = pool
+	}
+
+	hasCert := src.certFile != "" || src.certSecretRef != ""
+	hasKey := src.keyFile != "" || src.keySecretRef != ""
+	if hasCert != hasKey {
+		return nil, fmt.Errorf("TLS client certificate and key must be set together")
+	}
+	if hasCert {
+		certPEM, err := tlsPEM(ctx, src.certFile, src.certSecretRef)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to read TLS client certificate")
+		}
+		keyPEM, err := tlsPEM(ctx, src.keyFile, src.keySecretRef)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to read TLS client key")
+		}
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid TLS client certificate")
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
+
+func tlsPEM(ctx *context.Context, file, secretRef string) ([]byte, error) {
+	if secretRef != "" {
+		value, err := env.ResolveSecretValue(ctx.Config.CurrentVaultName(), secretRef)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(value), nil
+	}
+	return os.ReadFile(filepath.Clean(file))
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,51 @@
+package request
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/flowexec/flow/v2/internal/services/rest"
+	"github.com/flowexec/flow/v2/pkg/logger"
+)
+
+const (
+	// RecordEnv is the directory that requests and their responses are recorded to as fixtures.
+	RecordEnv = "FLOW_REQUEST_RECORD"
+	// ReplayEnv is the directory of the fixtures that responses are replayed from instead of sending requests.
+	ReplayEnv = "FLOW_REQUEST_REPLAY"
+	// ReplayMatchEnv is the comma-separated list of request fields (method, url, body) that a fixture must match to
+	// be replayed. All fields are matched when it isn't set.
+	ReplayMatchEnv = "FLOW_REQUEST_REPLAY_MATCH"
+)
+
+// fixturesFromEnv returns the fixtures that requests are recorded to or replayed from, or nil when neither is
+// configured.
+func fixturesFromEnv() (*rest.Fixtures, error) {
+	recordDir, replayDir := os.Getenv(RecordEnv), os.Getenv(ReplayEnv)
+	switch {
+	case recordDir != "" && replayDir != "":
+		return nil, errors.Errorf("requests can't be recorded and replayed at the same time (%s and %s are set)",
+			RecordEnv, ReplayEnv)
+	case recordDir != "":
+		dir, err := filepath.Abs(recordDir)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to resolve fixtures directory")
+		}
+		logger.Log().Debugf("recording requests to %s", dir)
+		return &rest.Fixtures{Mode: rest.FixtureModeRecord, Dir: dir}, nil
+	case replayDir != "":
+		dir, err := filepath.Abs(replayDir)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to resolve fixtures directory")
+		}
+		match, err := rest.ParseMatchFields(os.Getenv(ReplayMatchEnv))
+		if err != nil {
+			return nil, err
+		}
+		logger.Log().Debugf("replaying responses from %s", dir)
+		return &rest.Fixtures{Mode: rest.FixtureModeReplay, Dir: dir, Match: match}, nil
+	}
+	return nil, nil
+}
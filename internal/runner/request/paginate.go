@@ -0,0 +1,180 @@
+package request
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/jahvon/expression"
+	"github.com/pkg/errors"
+
+	"github.com/flowexec/flow/v2/internal/services/rest"
+	"github.com/flowexec/flow/v2/pkg/logger"
+	"github.com/flowexec/flow/v2/types/executable"
+)
+
+const (
+	defaultItemsExpr   = "fromJSON(body)"
+	defaultCursorParam = "cursor"
+	defaultPageParam   = "page"
+	defaultStartPage   = 1
+	defaultMaxPages    = 100
+)
+
+// paginate requests the pages of a paginated API and returns the last response, with its body replaced by a JSON
+// array of the items of every page.
+func paginate(
+	req *rest.Request,
+	validStatusCodes []int,
+	spec *executable.RequestPaginate,
+) (*rest.Response, error) {
+	itemsExpr := spec.Items
+	if itemsExpr == "" {
+		itemsExpr = defaultItemsExpr
+	}
+	maxPages := spec.MaxPages
+	if maxPages <= 0 {
+		maxPages = defaultMaxPages
+	}
+	page := defaultStartPage
+	if spec.StartPage != nil {
+		page = *spec.StartPage
+	}
+
+	pageReq := *req
+	if spec.Strategy == executable.RequestPaginateStrategyPage {
+		nextURL, err := withQueryParam(req.URL, firstNonEmpty(spec.PageParam, defaultPageParam), strconv.Itoa(page))
+		if err != nil {
+			return nil, err
+		}
+		pageReq.URL = nextURL
+	}
+
+	items := make([]any, 0)
+	var resp *rest.Response
+	for pageNum := 1; ; pageNum++ {
+		var err error
+		resp, err = rest.SendRequest(&pageReq, validStatusCodes)
+		if err != nil {
+			return nil, errors.Wrapf(statusCodeError(err), "request for page %d failed", pageNum)
+		}
+		pageItems, err := selectItems(itemsExpr, resp)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, pageItems...)
+		logger.Log().Debugf("collected %d items from page %d of %s", len(pageItems), pageNum, pageReq.URL)
+
+		nextURL, err := nextPageURL(&pageReq, resp, spec, page+pageNum, len(pageItems))
+		if err != nil {
+			return nil, err
+		}
+		if nextURL == "" {
+			break
+		}
+		if pageNum >= maxPages {
+			logger.Log().Warnf("stopped paginating %s after %d pages; more pages are available", req.URL, maxPages)
+			break
+		}
+		pageReq.URL = nextURL
+	}
+
+	body, err := json.Marshal(items)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to encode paginated items")
+	}
+	combined := *resp
+	combined.Body = string(body)
+	return &combined, nil
+}
+
+// nextPageURL returns the URL of the page after the response, or "" when it's the last page.
+func nextPageURL(
+	req *rest.Request,
+	resp *rest.Response,
+	spec *executable.RequestPaginate,
+	nextPage, itemCount int,
+) (string, error) {
+	switch spec.Strategy {
+	case executable.RequestPaginateStrategyLink:
+		next := nextLink(resp.Headers.Values("Link"))
+		if next == "" {
+			return "", nil
+		}
+		base, err := url.Parse(req.URL)
+		if err != nil {
+			return "", errors.Wrap(err, "unable to parse request URL")
+		}
+		ref, err := url.Parse(next)
+		if err != nil {
+			return "", errors.Wrapf(err, "unable to parse next page link %s", next)
+		}
+		return base.ResolveReference(ref).String(), nil
+	case executable.RequestPaginateStrategyCursor:
+		cursor, err := expression.EvaluateString(spec.Cursor, resp)
+		if err != nil {
+			return "", errors.Wrapf(err, "unable to evaluate cursor expression `%s`", spec.Cursor)
+		}
+		if cursor == "" {
+			return "", nil
+		}
+		return withQueryParam(req.URL, firstNonEmpty(spec.CursorParam, defaultCursorParam), cursor)
+	case executable.RequestPaginateStrategyPage:
+		if itemCount == 0 {
+			return "", nil
+		}
+		return withQueryParam(req.URL, firstNonEmpty(spec.PageParam, defaultPageParam), strconv.Itoa(nextPage))
+	}
+	return "", fmt.Errorf("unsupported pagination strategy %q", spec.Strategy)
+}
+
+// selectItems evaluates the items expression of a page, which must return a list.
+func selectItems(itemsExpr string, resp *rest.Response) ([]any, error) {
+	result, err := expression.Evaluate(itemsExpr, resp)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to evaluate items expression `%s`", itemsExpr)
+	}
+	if result == nil {
+		return nil, nil
+	}
+	v := reflect.ValueOf(result)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("items expression `%s` returned %T, not a list", itemsExpr, result)
+	}
+	items := make([]any, v.Len())
+	for i := range items {
+		items[i] = v.Index(i).Interface()
+	}
+	return items, nil
+}
+
+// nextLink returns the URL of the `rel="next"` link of Link headers (RFC 8288).
+func nextLink(headers []string) string {
+	for _, header := range headers {
+		for _, link := range strings.Split(header, ",") {
+			target, params, found := strings.Cut(strings.TrimSpace(link), ";")
+			if !found || !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+			for _, param := range strings.Split(params, ";") {
+				key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+				if !strings.EqualFold(key, "rel") {
+					continue
+				}
+				for _, rel := range strings.Fields(strings.Trim(value, `"`)) {
+					if strings.EqualFold(rel, "next") {
+						return strings.TrimSuffix(strings.TrimPrefix(target, "<"), ">")
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func withQueryParam(rawURL, key, value string) (string, error) {
+	return withQuery(rawURL, map[string]string{key: value}, nil)
+}
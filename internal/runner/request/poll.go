@@ -0,0 +1,86 @@
+package request
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jahvon/expression"
+	"github.com/pkg/errors"
+
+	"github.com/flowexec/flow/v2/internal/services/rest"
+	"github.com/flowexec/flow/v2/pkg/context"
+	"github.com/flowexec/flow/v2/pkg/logger"
+	"github.com/flowexec/flow/v2/types/executable"
+)
+
+const (
+	defaultPollInterval = 5 * time.Second
+	defaultPollTimeout  = 5 * time.Minute
+)
+
+// sendRequest sends the request once, until its `poll.until` condition is met when polling is configured, or for
+// every page when pagination is configured.
+func sendRequest(ctx *context.Context, req *rest.Request, spec *executable.RequestExecutableType) (*rest.Response, error) {
+	switch {
+	case spec.Poll != nil:
+		return poll(ctx, req, spec.ValidStatusCodes, spec.Poll)
+	case spec.Paginate != nil:
+		return paginate(req, spec.ValidStatusCodes, spec.Paginate)
+	}
+	resp, err := rest.SendRequest(req, spec.ValidStatusCodes)
+	if err != nil {
+		return nil, errors.Wrap(statusCodeError(err), "request failed")
+	}
+	return resp, nil
+}
+
+// poll sends the request every interval until the response matches the `until` expression. Failed requests and
+// unexpected status codes are retried. When the timeout is reached, the error includes the last response.
+func poll(
+	ctx *context.Context,
+	req *rest.Request,
+	validStatusCodes []int,
+	spec *executable.RequestPoll,
+) (*rest.Response, error) {
+	interval, timeout := spec.Interval, spec.Timeout
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	if timeout <= 0 {
+		timeout = defaultPollTimeout
+	}
+
+	deadline := time.Now().Add(timeout)
+	var lastErr error
+	for attempt := 1; ; attempt++ {
+		resp, err := rest.SendRequest(req, validStatusCodes)
+		switch {
+		case errors.Is(err, rest.ErrNoMatchingFixture):
+			return nil, err
+		case err != nil:
+			lastErr = statusCodeError(err)
+			logger.Log().Debugf("poll attempt %d to %s failed: %v", attempt, req.URL, err)
+		default:
+			done, err := expression.IsTruthy(spec.Until, resp)
+			if err != nil {
+				return nil, errors.Wrapf(err, "unable to evaluate poll condition `%s`", spec.Until)
+			}
+			logger.Log().Debugf("poll attempt %d to %s: %s, `%s` is %t", attempt, req.URL, resp.Status, spec.Until, done)
+			if done {
+				return resp, nil
+			}
+			lastErr = fmt.Errorf("last response (%s): %s", resp.Status, truncateBody(resp.Body))
+		}
+
+		if time.Now().Add(interval).After(deadline) {
+			return nil, fmt.Errorf(
+				"poll condition `%s` not met after %s (%d attempts) - %w", spec.Until, timeout, attempt, lastErr,
+			)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, errors.Wrap(ctx.Err(), "polling canceled")
+		case <-time.After(interval):
+		}
+	}
+}
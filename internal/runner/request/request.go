@@ -43,41 +43,98 @@ func (r *requestRunner) Exec(
 	inputEnv map[string]string,
 	inputArgs []string,
 ) error {
-	requestSpec := e.Request
 	envMap, err := env.BuildEnvMap(
 		ctx.Config.CurrentVaultName(), e.Env(), inputArgs, inputEnv, env.DefaultEnv(ctx, e),
 	)
 	if err != nil {
 		return errors.Wrap(err, "unable to set parameters to env")
 	}
+	if err := AddCapturedValues(ctx, envMap); err != nil {
+		return err
+	}
 
-	url := expandEnvVars(envMap, requestSpec.URL)
-	body := expandEnvVars(envMap, requestSpec.Body)
-	if body != "" {
-		body, err = expression.EvaluateString(body, map[string]interface{}{"env": envMap})
-		if err != nil {
-			return errors.Wrap(err, "unable to evaluate request body expression")
-		}
+	resp, err := Send(ctx, e, envMap)
+	if err != nil {
+		return err
+	}
+	return HandleResponse(ctx, e, envMap, resp, resp)
+}
+
+// Send builds the request of the executable and sends it, polling or paginating when it's configured.
+func Send(ctx *context.Context, e *executable.Executable, envMap map[string]string) (*rest.Response, error) {
+	if err := e.Request.Validate(); err != nil {
+		return nil, err
+	}
+	body, contentType, err := requestBody(e, envMap)
+	if err != nil {
+		return nil, err
+	}
+	return SendWithBody(ctx, e, envMap, body, contentType)
+}
+
+// SendWithBody sends the request of the executable with a body that's already built. Environment variables in the
+// body are not expanded.
+func SendWithBody(
+	ctx *context.Context,
+	e *executable.Executable,
+	envMap map[string]string,
+	body, contentType string,
+) (*rest.Response, error) {
+	requestSpec := e.Request
+	if err := requestSpec.Validate(); err != nil {
+		return nil, err
+	}
+	url, err := withQuery(expandEnvVars(envMap, requestSpec.URL), requestSpec.Query, envMap)
+	if err != nil {
+		return nil, err
 	}
 
+	headers := make(map[string]string, len(requestSpec.Headers))
 	for key, value := range requestSpec.Headers {
-		requestSpec.Headers[key] = expandEnvVars(envMap, value)
+		headers[key] = expandEnvVars(envMap, value)
+	}
+	if contentType != "" && !hasHeader(headers, contentTypeHeader) {
+		headers[contentTypeHeader] = contentType
+	}
+	clientOpts, err := clientOptions(ctx, e, envMap)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to configure HTTP client")
 	}
 	restRequest := rest.Request{
 		URL:     url,
 		Method:  string(requestSpec.Method),
-		Headers: requestSpec.Headers,
+		Headers: headers,
 		Body:    body,
 		Timeout: requestSpec.Timeout,
+		Client:  clientOpts,
 	}
-	resp, err := rest.SendRequest(&restRequest, requestSpec.ValidStatusCodes)
-	if err != nil {
-		return errors.Wrap(err, "request failed")
+	if err := applyAuth(ctx, requestSpec.Auth, envMap, &restRequest); err != nil {
+		return nil, errors.Wrap(err, "unable to authenticate request")
+	}
+	return sendRequest(ctx, &restRequest, requestSpec)
+}
+
+// HandleResponse checks the assertions of the executable's request and captures, transforms, logs and saves its
+// response. Expressions are evaluated over data, which is usually the response itself.
+func HandleResponse(
+	ctx *context.Context,
+	e *executable.Executable,
+	envMap map[string]string,
+	resp *rest.Response,
+	data expression.Data,
+) error {
+	requestSpec := e.Request
+	if err := checkAssertions(requestSpec.Assert, data, resp); err != nil {
+		return err
+	}
+	if err := captureValues(ctx, requestSpec.Capture, data); err != nil {
+		return err
 	}
 
 	respStr := resp.Body
 	if requestSpec.TransformResponse != "" {
-		respStr, err = expression.EvaluateString(requestSpec.TransformResponse, resp)
+		var err error
+		respStr, err = expression.EvaluateString(requestSpec.TransformResponse, data)
 		if err != nil {
 			return errors.Wrap(err, "unable to transform response")
 		}
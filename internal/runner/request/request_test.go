@@ -2,11 +2,22 @@ package request_test
 
 import (
 	stdCtx "context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"mime"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -15,8 +26,11 @@ import (
 	"github.com/flowexec/flow/v2/internal/runner"
 	"github.com/flowexec/flow/v2/internal/runner/engine/mocks"
 	"github.com/flowexec/flow/v2/internal/runner/request"
+	"github.com/flowexec/flow/v2/pkg/store"
 	testUtils "github.com/flowexec/flow/v2/tests/utils"
+	"github.com/flowexec/flow/v2/types/common"
 	"github.com/flowexec/flow/v2/types/executable"
+	"github.com/flowexec/flow/v2/types/workspace"
 )
 
 func TestRequest(t *testing.T) {
@@ -144,5 +158,664 @@ var _ = Describe("Request Runner", func() {
 			err := requestRnr.Exec(ctx.Ctx, exec, mockEngine, make(map[string]string), nil)
 			Expect(err).NotTo(HaveOccurred())
 		})
+
+		Context("with auth", func() {
+			var (
+				authServer *httptest.Server
+				gotHeader  http.Header
+				gotQuery   string
+				tokenCalls int
+			)
+
+			BeforeEach(func() {
+				tokenCalls = 0
+				authServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if r.URL.Path == "/token" {
+						tokenCalls++
+						Expect(r.ParseForm()).To(Succeed())
+						Expect(r.PostForm.Get("grant_type")).To(Equal("client_credentials"))
+						Expect(r.PostForm.Get("scope")).To(Equal("read write"))
+						user, pass, ok := r.BasicAuth()
+						Expect(ok).To(BeTrue())
+						Expect(user + ":" + pass).To(Equal("client:s3cret"))
+						_, _ = w.Write([]byte(`{"access_token": "issued-token", "token_type": "Bearer", "expires_in": 3600}`))
+						return
+					}
+					gotHeader, gotQuery = r.Header, r.URL.RawQuery
+					_, _ = w.Write([]byte(`{}`))
+				}))
+				DeferCleanup(authServer.Close)
+				ctx.Logger.EXPECT().Infof(gomock.Any(), gomock.Any()).AnyTimes()
+			})
+
+			exec := func(auth *executable.RequestAuth, path string, env map[string]string) error {
+				e := &executable.Executable{
+					Request: &executable.RequestExecutableType{URL: authServer.URL + path, Auth: auth},
+				}
+				return requestRnr.Exec(ctx.Ctx, e, mockEngine, env, nil)
+			}
+
+			It("should send basic auth credentials", func() {
+				auth := &executable.RequestAuth{Basic: &executable.RequestBasicAuth{Username: "admin", Password: "$PASS"}}
+				Expect(exec(auth, "/", map[string]string{"PASS": "hunter2"})).To(Succeed())
+				Expect(gotHeader.Get("Authorization")).To(Equal(
+					"Basic " + base64.StdEncoding.EncodeToString([]byte("admin:hunter2")),
+				))
+			})
+
+			It("should send a bearer token", func() {
+				auth := &executable.RequestAuth{Bearer: &executable.RequestBearerAuth{Token: "$TOKEN"}}
+				Expect(exec(auth, "/", map[string]string{"TOKEN": "abc"})).To(Succeed())
+				Expect(gotHeader.Get("Authorization")).To(Equal("Bearer abc"))
+			})
+
+			It("should send an api key in a header or the query", func() {
+				auth := &executable.RequestAuth{ApiKey: &executable.RequestAPIKeyAuth{Name: "X-API-Key", Value: "key1"}}
+				Expect(exec(auth, "/", nil)).To(Succeed())
+				Expect(gotHeader.Get("X-API-Key")).To(Equal("key1"))
+
+				auth = &executable.RequestAuth{ApiKey: &executable.RequestAPIKeyAuth{
+					Name: "api_key", Value: "key2", In: executable.RequestAPIKeyAuthInQuery,
+				}}
+				Expect(exec(auth, "/items?page=2", nil)).To(Succeed())
+				Expect(gotQuery).To(Equal("api_key=key2&page=2"))
+			})
+
+			It("should fetch and cache oauth2 client credentials tokens", func() {
+				ds, err := store.NewDataStore(filepath.Join(GinkgoT().TempDir(), "store.db"))
+				Expect(err).NotTo(HaveOccurred())
+				DeferCleanup(ds.Close)
+				ctx.Ctx.DataStore = ds
+
+				auth := &executable.RequestAuth{Oauth2: &executable.RequestOAuth2Auth{
+					TokenURL:     authServer.URL + "/token",
+					ClientId:     "client",
+					ClientSecret: "$CLIENT_SECRET",
+					Scopes:       []string{"read", "write"},
+				}}
+				env := map[string]string{"CLIENT_SECRET": "s3cret"}
+				Expect(exec(auth, "/", env)).To(Succeed())
+				Expect(gotHeader.Get("Authorization")).To(Equal("Bearer issued-token"))
+				Expect(exec(auth, "/", env)).To(Succeed())
+				Expect(gotHeader.Get("Authorization")).To(Equal("Bearer issued-token"))
+				Expect(tokenCalls).To(Equal(1))
+			})
+
+			It("should fail when more than one auth method is set", func() {
+				auth := &executable.RequestAuth{
+					Bearer: &executable.RequestBearerAuth{Token: "abc"},
+					ApiKey: &executable.RequestAPIKeyAuth{Name: "X-API-Key", Value: "key"},
+				}
+				Expect(exec(auth, "/", nil)).To(MatchError(ContainSubstring("must define only one auth method")))
+			})
+		})
+
+		Context("with body options", func() {
+			var (
+				echoServer *httptest.Server
+				got        *http.Request
+				gotBody    []byte
+				flowDir    string
+			)
+
+			BeforeEach(func() {
+				echoServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					got = r
+					gotBody, _ = io.ReadAll(r.Body)
+				}))
+				DeferCleanup(echoServer.Close)
+				flowDir = GinkgoT().TempDir()
+				ctx.Logger.EXPECT().Infof(gomock.Any(), gomock.Any()).AnyTimes()
+			})
+
+			exec := func(spec *executable.RequestExecutableType) error {
+				spec.URL = echoServer.URL + "/upload?v=1"
+				e := &executable.Executable{Request: spec}
+				e.SetContext(ctx.Ctx.CurrentWorkspace.AssignedName(), flowDir, "", filepath.Join(flowDir, "test.flow"))
+				return requestRnr.Exec(ctx.Ctx, e, mockEngine, map[string]string{"NAME": "flow app"}, nil)
+			}
+
+			It("should add query params to the URL", func() {
+				Expect(exec(&executable.RequestExecutableType{
+					Method: executable.RequestExecutableTypeMethodHEAD,
+					Query:  map[string]string{"name": "$NAME", "page": "2"},
+				})).To(Succeed())
+				Expect(got.Method).To(Equal(http.MethodHead))
+				Expect(got.URL.RawQuery).To(Equal("name=flow+app&page=2&v=1"))
+			})
+
+			It("should omit query params whose env vars are empty", func() {
+				Expect(exec(&executable.RequestExecutableType{
+					Query: map[string]string{"name": "$NAME", "limit": "$LIMIT"},
+				})).To(Succeed())
+				Expect(got.URL.RawQuery).To(Equal("name=flow+app&v=1"))
+			})
+
+			It("should send the body when the body file path is empty", func() {
+				Expect(os.WriteFile(filepath.Join(flowDir, "user.json"), []byte(`{"id": 1}`), 0600)).To(Succeed())
+				spec := func() *executable.RequestExecutableType {
+					return &executable.RequestExecutableType{
+						Method:   executable.RequestExecutableTypeMethodPOST,
+						Body:     `{"name": "$NAME"}`,
+						BodyFile: "$BODY_FILE",
+					}
+				}
+				Expect(exec(spec())).To(Succeed())
+				Expect(string(gotBody)).To(Equal(`{"name": "flow app"}`))
+
+				e := &executable.Executable{Request: spec()}
+				e.Request.URL = echoServer.URL
+				e.SetContext(ctx.Ctx.CurrentWorkspace.AssignedName(), flowDir, "", filepath.Join(flowDir, "test.flow"))
+				Expect(requestRnr.Exec(ctx.Ctx, e, mockEngine, map[string]string{"BODY_FILE": "user.json"}, nil)).
+					To(Succeed())
+				Expect(string(gotBody)).To(Equal(`{"id": 1}`))
+			})
+
+			It("should send JSON and form bodies with their content type", func() {
+				Expect(exec(&executable.RequestExecutableType{
+					Method: executable.RequestExecutableTypeMethodPOST,
+					Body:   `{"name": "$NAME"}`,
+				})).To(Succeed())
+				Expect(got.Header.Get("Content-Type")).To(Equal("application/json"))
+				Expect(string(gotBody)).To(Equal(`{"name": "flow app"}`))
+
+				Expect(exec(&executable.RequestExecutableType{
+					Method: executable.RequestExecutableTypeMethodPOST,
+					Form:   map[string]string{"name": "$NAME"},
+				})).To(Succeed())
+				Expect(got.Header.Get("Content-Type")).To(Equal("application/x-www-form-urlencoded"))
+				Expect(string(gotBody)).To(Equal("name=flow+app"))
+			})
+
+			It("should send a file body", func() {
+				Expect(os.WriteFile(filepath.Join(flowDir, "data.json"), []byte(`[1, 2]`), 0600)).To(Succeed())
+				Expect(exec(&executable.RequestExecutableType{
+					Method:   executable.RequestExecutableTypeMethodPUT,
+					BodyFile: "data.json",
+					Headers:  map[string]string{"content-type": "application/vnd.api+json"},
+				})).To(Succeed())
+				Expect(got.Header.Get("Content-Type")).To(Equal("application/vnd.api+json"))
+				Expect(got.ContentLength).To(Equal(int64(6)))
+				Expect(string(gotBody)).To(Equal(`[1, 2]`))
+			})
+
+			It("should send a multipart body with files relative to the flow file", func() {
+				Expect(os.WriteFile(filepath.Join(flowDir, "app.tar.gz"), []byte("artifact"), 0600)).To(Succeed())
+				Expect(exec(&executable.RequestExecutableType{
+					Method: executable.RequestExecutableTypeMethodPOST,
+					Multipart: &executable.RequestMultipart{
+						Fields: map[string]string{"name": "$NAME"},
+						Files:  map[string]string{"artifact": "app.tar.gz"},
+					},
+				})).To(Succeed())
+
+				mediaType, params, err := mime.ParseMediaType(got.Header.Get("Content-Type"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mediaType).To(Equal("multipart/form-data"))
+				form, err := multipart.NewReader(strings.NewReader(string(gotBody)), params["boundary"]).ReadForm(1 << 20)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(form.Value["name"]).To(Equal([]string{"flow app"}))
+				Expect(form.File["artifact"]).To(HaveLen(1))
+				Expect(form.File["artifact"][0].Filename).To(Equal("app.tar.gz"))
+			})
+
+			It("should fail when more than one body option is set", func() {
+				err := exec(&executable.RequestExecutableType{
+					Method: executable.RequestExecutableTypeMethodPOST,
+					Body:   "raw",
+					Form:   map[string]string{"a": "b"},
+				})
+				Expect(err).To(MatchError(ContainSubstring("must define only one of body, rawBody, bodyFile, form or multipart")))
+			})
+
+			It("should send a raw body without evaluating it", func() {
+				Expect(exec(&executable.RequestExecutableType{
+					Method:  executable.RequestExecutableTypeMethodPOST,
+					RawBody: `<user name="$NAME">"quoted" \ text</user>`,
+					Headers: map[string]string{"Content-Type": "application/xml"},
+				})).To(Succeed())
+				Expect(got.Header.Get("Content-Type")).To(Equal("application/xml"))
+				Expect(string(gotBody)).To(Equal(`<user name="flow app">"quoted" \ text</user>`))
+			})
+		})
+
+		Context("with client options", func() {
+			var (
+				tlsServer *httptest.Server
+				wsDir     string
+			)
+
+			BeforeEach(func() {
+				tlsServer = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					_, _ = w.Write([]byte(`{"clientCerts": ` + strconv.Itoa(len(r.TLS.PeerCertificates)) + `}`))
+				}))
+				tlsServer.TLS = &tls.Config{ClientAuth: tls.RequestClientCert, MinVersion: tls.VersionTLS12}
+				tlsServer.StartTLS()
+				DeferCleanup(tlsServer.Close)
+				wsDir = ctx.Ctx.CurrentWorkspace.Location()
+				Expect(os.WriteFile(filepath.Join(wsDir, "ca.pem"), pem.EncodeToMemory(&pem.Block{
+					Type: "CERTIFICATE", Bytes: tlsServer.Certificate().Raw,
+				}), 0600)).To(Succeed())
+				ctx.Logger.EXPECT().Infof(gomock.Any(), gomock.Any()).AnyTimes()
+			})
+
+			exec := func(spec *executable.RequestExecutableType) error {
+				if spec.URL == "" {
+					spec.URL = tlsServer.URL
+				}
+				e := &executable.Executable{Request: spec}
+				e.SetContext(ctx.Ctx.CurrentWorkspace.AssignedName(), wsDir, "", filepath.Join(wsDir, "app", "app.flow"))
+				return requestRnr.Exec(ctx.Ctx, e, mockEngine, nil, nil)
+			}
+
+			It("should verify the server with a CA file", func() {
+				Expect(exec(&executable.RequestExecutableType{})).To(MatchError(ContainSubstring("certificate")))
+				Expect(exec(&executable.RequestExecutableType{
+					Tls: &executable.RequestExecutableTypeTls{CaFile: "//ca.pem"},
+				})).To(Succeed())
+			})
+
+			It("should warn when certificate verification is skipped", func() {
+				ctx.Logger.EXPECT().Warnf(gomock.Any(), gomock.Any()).Times(1)
+				Expect(exec(&executable.RequestExecutableType{
+					Tls: &executable.RequestExecutableTypeTls{InsecureSkipVerify: true},
+				})).To(Succeed())
+			})
+
+			It("should send a client certificate from the workspace request defaults", func() {
+				cert := tlsServer.TLS.Certificates[0]
+				key, err := x509.MarshalPKCS8PrivateKey(cert.PrivateKey)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(os.WriteFile(filepath.Join(wsDir, "client.pem"), pem.EncodeToMemory(&pem.Block{
+					Type: "CERTIFICATE", Bytes: cert.Certificate[0],
+				}), 0600)).To(Succeed())
+				Expect(os.WriteFile(filepath.Join(wsDir, "client-key.pem"), pem.EncodeToMemory(&pem.Block{
+					Type: "PRIVATE KEY", Bytes: key,
+				}), 0600)).To(Succeed())
+				ctx.Ctx.CurrentWorkspace.RequestDefaults = &workspace.WorkspaceRequestDefaults{
+					Tls: &common.RequestTLS{CaFile: "ca.pem", CertFile: "client.pem", KeyFile: "client-key.pem"},
+				}
+
+				Expect(exec(&executable.RequestExecutableType{
+					Assert: []string{"fromJSON(body).clientCerts == 1"},
+				})).To(Succeed())
+			})
+
+			It("should fail when only a client certificate is set", func() {
+				Expect(exec(&executable.RequestExecutableType{
+					Tls: &executable.RequestExecutableTypeTls{CertFile: "//ca.pem"},
+				})).To(MatchError(ContainSubstring("TLS client certificate and key must be set together")))
+			})
+
+			It("should send requests through a proxy", func() {
+				var proxiedURL string
+				proxy := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+					proxiedURL = r.URL.String()
+				}))
+				DeferCleanup(proxy.Close)
+				Expect(exec(&executable.RequestExecutableType{
+					URL:   "http://api.internal.test/items",
+					Proxy: proxy.URL,
+				})).To(Succeed())
+				Expect(proxiedURL).To(Equal("http://api.internal.test/items"))
+			})
+
+			It("should follow redirects unless disabled", func() {
+				redirects := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if n, _ := strconv.Atoi(r.URL.Query().Get("n")); n > 0 {
+						http.Redirect(w, r, "/?n="+strconv.Itoa(n-1), http.StatusFound)
+					}
+				}))
+				DeferCleanup(redirects.Close)
+
+				Expect(exec(&executable.RequestExecutableType{URL: redirects.URL + "/?n=2"})).To(Succeed())
+				Expect(exec(&executable.RequestExecutableType{
+					URL:          redirects.URL + "/?n=1",
+					MaxRedirects: ptr(1),
+				})).To(Succeed())
+				Expect(exec(&executable.RequestExecutableType{
+					URL:          redirects.URL + "/?n=2",
+					MaxRedirects: ptr(1),
+				})).To(MatchError(ContainSubstring("stopped after 1 redirects")))
+
+				ctx.Ctx.CurrentWorkspace.RequestDefaults = &workspace.WorkspaceRequestDefaults{FollowRedirects: ptr(false)}
+				Expect(exec(&executable.RequestExecutableType{
+					URL:              redirects.URL + "/?n=2",
+					ValidStatusCodes: []int{http.StatusFound},
+					Assert:           []string{`headers["Location"][0] == "/?n=1"`},
+				})).To(Succeed())
+			})
+		})
+
+		Context("with polling", func() {
+			var (
+				jobServer *httptest.Server
+				calls     int
+			)
+
+			BeforeEach(func() {
+				calls = 0
+				jobServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					calls++
+					switch {
+					case calls == 1:
+						w.WriteHeader(http.StatusServiceUnavailable)
+					case calls < 4:
+						_, _ = w.Write([]byte(`{"state": "running"}`))
+					default:
+						_, _ = w.Write([]byte(`{"state": "done", "result": "ok"}`))
+					}
+				}))
+				DeferCleanup(jobServer.Close)
+			})
+
+			exec := func(spec *executable.RequestExecutableType) error {
+				spec.URL = jobServer.URL
+				return requestRnr.Exec(ctx.Ctx, &executable.Executable{Request: spec}, mockEngine, nil, nil)
+			}
+
+			It("should poll until the condition is met and transform the final response", func() {
+				ctx.Logger.EXPECT().Info(gomock.Any(), gomock.Any(), "ok").Times(1)
+				Expect(exec(&executable.RequestExecutableType{
+					Poll: &executable.RequestPoll{
+						Until:    `fromJSON(body).state == "done"`,
+						Interval: 10 * time.Millisecond,
+						Timeout:  5 * time.Second,
+					},
+					TransformResponse: `fromJSON(body).result`,
+					LogResponse:       true,
+				})).To(Succeed())
+				Expect(calls).To(Equal(4))
+			})
+
+			It("should fail with the last response when the timeout is reached", func() {
+				err := exec(&executable.RequestExecutableType{
+					Poll: &executable.RequestPoll{
+						Until:    `fromJSON(body).state == "failed"`,
+						Interval: 10 * time.Millisecond,
+						Timeout:  25 * time.Millisecond,
+					},
+				})
+				Expect(err).To(MatchError(ContainSubstring("poll condition `fromJSON(body).state == \"failed\"` not met after 25ms")))
+				Expect(err).To(MatchError(ContainSubstring(`last response (200 OK): {"state": "running"}`)))
+			})
+
+			It("should fail when the until expression is empty", func() {
+				err := exec(&executable.RequestExecutableType{Poll: &executable.RequestPoll{}})
+				Expect(err).To(MatchError(ContainSubstring("poll until expression cannot be empty")))
+			})
+		})
+
+		Context("with pagination", func() {
+			var (
+				pageServer *httptest.Server
+				requested  []string
+			)
+
+			BeforeEach(func() {
+				requested = nil
+				pages := [][]string{{"a", "b"}, {"c"}, {"d"}}
+				pageServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					requested = append(requested, r.URL.RawQuery)
+					index := 0
+					switch {
+					case r.URL.Query().Has("page"):
+						index, _ = strconv.Atoi(r.URL.Query().Get("page"))
+						index--
+					case r.URL.Query().Has("after"):
+						index, _ = strconv.Atoi(r.URL.Query().Get("after"))
+					case r.URL.Query().Has("p"):
+						index, _ = strconv.Atoi(r.URL.Query().Get("p"))
+					}
+					if r.URL.Path == "/link" && index < len(pages)-1 {
+						w.Header().Add("Link", `</first>; rel="first", </link?p=`+strconv.Itoa(index+1)+`>; rel="next"`)
+					}
+					var items []string
+					if index < len(pages) {
+						items = pages[index]
+					}
+					next := ""
+					if index < len(pages)-1 {
+						next = strconv.Itoa(index + 1)
+					}
+					_ = json.NewEncoder(w).Encode(map[string]any{"data": items, "next": next})
+				}))
+				DeferCleanup(pageServer.Close)
+			})
+
+			exec := func(path string, spec *executable.RequestExecutableType) error {
+				spec.URL = pageServer.URL + path
+				spec.LogResponse = true
+				return requestRnr.Exec(ctx.Ctx, &executable.Executable{Request: spec}, mockEngine, nil, nil)
+			}
+
+			It("should follow Link headers", func() {
+				ctx.Logger.EXPECT().Info(gomock.Any(), gomock.Any(), `["a","b","c","d"]`).Times(1)
+				Expect(exec("/link", &executable.RequestExecutableType{
+					Paginate: &executable.RequestPaginate{
+						Strategy: executable.RequestPaginateStrategyLink,
+						Items:    "fromJSON(body).data",
+					},
+				})).To(Succeed())
+				Expect(requested).To(Equal([]string{"", "p=1", "p=2"}))
+			})
+
+			It("should send the cursor of the previous page and transform the combined items", func() {
+				ctx.Logger.EXPECT().Info(gomock.Any(), gomock.Any(), "a,b,c,d").Times(1)
+				Expect(exec("/items?limit=2", &executable.RequestExecutableType{
+					Paginate: &executable.RequestPaginate{
+						Strategy:    executable.RequestPaginateStrategyCursor,
+						Items:       "fromJSON(body).data",
+						Cursor:      "fromJSON(body).next",
+						CursorParam: "after",
+					},
+					TransformResponse: `join(fromJSON(body), ",")`,
+				})).To(Succeed())
+				Expect(requested).To(Equal([]string{"limit=2", "after=1&limit=2", "after=2&limit=2"}))
+			})
+
+			It("should request page numbers until a page is empty", func() {
+				ctx.Logger.EXPECT().Info(gomock.Any(), gomock.Any(), `["a","b","c","d"]`).Times(1)
+				Expect(exec("/items", &executable.RequestExecutableType{
+					Paginate: &executable.RequestPaginate{
+						Strategy: executable.RequestPaginateStrategyPage,
+						Items:    "fromJSON(body).data",
+					},
+				})).To(Succeed())
+				Expect(requested).To(Equal([]string{"page=1", "page=2", "page=3", "page=4"}))
+			})
+
+			It("should stop at the max pages and warn", func() {
+				ctx.Logger.EXPECT().Warnf(gomock.Any(), gomock.Any()).Times(1)
+				ctx.Logger.EXPECT().Info(gomock.Any(), gomock.Any(), `["a","b","c"]`).Times(1)
+				Expect(exec("/items", &executable.RequestExecutableType{
+					Paginate: &executable.RequestPaginate{
+						Strategy: executable.RequestPaginateStrategyPage,
+						Items:    "fromJSON(body).data",
+						MaxPages: 2,
+					},
+				})).To(Succeed())
+			})
+		})
+
+		Context("with assertions and captures", func() {
+			var (
+				statusServer *httptest.Server
+				gotPath      string
+			)
+
+			BeforeEach(func() {
+				statusServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					gotPath = r.URL.Path
+					if r.URL.Path == "/fail" {
+						w.WriteHeader(http.StatusInternalServerError)
+						_, _ = w.Write([]byte(strings.Repeat("x", 1000)))
+						return
+					}
+					w.Header().Set("X-Request-Id", "req-1")
+					_, _ = w.Write([]byte(`{"id": 42, "ready": false}`))
+				}))
+				DeferCleanup(statusServer.Close)
+			})
+
+			exec := func(path string, spec *executable.RequestExecutableType) error {
+				spec.URL = statusServer.URL + path
+				return requestRnr.Exec(ctx.Ctx, &executable.Executable{Request: spec}, mockEngine, nil, nil)
+			}
+
+			It("should include the response body when the status code isn't valid", func() {
+				err := exec("/fail", &executable.RequestExecutableType{})
+				Expect(err).To(MatchError(ContainSubstring("unexpected status code 500 Internal Server Error: xxx")))
+				Expect(err).To(MatchError(ContainSubstring("(488 bytes truncated)")))
+			})
+
+			It("should fail with the expression of the first failed assertion", func() {
+				err := exec("/", &executable.RequestExecutableType{
+					Assert: []string{"code == 200", "fromJSON(body).ready == true"},
+				})
+				Expect(err).To(MatchError(
+					"assertion `fromJSON(body).ready == true` failed (200 OK): {\"id\": 42, \"ready\": false}",
+				))
+			})
+
+			It("should save captured values to the process store", func() {
+				ds, err := store.NewDataStore(filepath.Join(GinkgoT().TempDir(), "store.db"))
+				Expect(err).NotTo(HaveOccurred())
+				DeferCleanup(ds.Close)
+				ctx.Ctx.DataStore = ds
+
+				ctx.Logger.EXPECT().Infof(gomock.Any(), gomock.Any()).Times(1)
+				Expect(exec("/", &executable.RequestExecutableType{
+					Assert:  []string{"code == 200"},
+					Capture: map[string]string{"id": "fromJSON(body).id", "requestId": `headers["X-Request-Id"][0]`},
+				})).To(Succeed())
+
+				vars, err := ds.GetAllProcessVars(store.EnvironmentBucket())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(vars).To(HaveKeyWithValue("id", "42"))
+				Expect(vars).To(HaveKeyWithValue("requestId", "req-1"))
+			})
+
+			It("should expand captured values in later requests", func() {
+				ds, err := store.NewDataStore(filepath.Join(GinkgoT().TempDir(), "store.db"))
+				Expect(err).NotTo(HaveOccurred())
+				DeferCleanup(ds.Close)
+				ctx.Ctx.DataStore = ds
+
+				ctx.Logger.EXPECT().Infof(gomock.Any(), gomock.Any()).Times(2)
+				Expect(exec("/", &executable.RequestExecutableType{
+					Capture: map[string]string{"item.id": "fromJSON(body).id"},
+				})).To(Succeed())
+				Expect(exec("/items/${item.id}", &executable.RequestExecutableType{})).To(Succeed())
+				Expect(gotPath).To(Equal("/items/42"))
+			})
+		})
+
+		Context("with fixtures", func() {
+			var (
+				fixturesServer *httptest.Server
+				fixturesDir    string
+			)
+
+			BeforeEach(func() {
+				fixturesServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					_, _ = w.Write([]byte(`{"id": 7}`))
+				}))
+				DeferCleanup(fixturesServer.Close)
+				fixturesDir = GinkgoT().TempDir()
+			})
+
+			exec := func(body string) error {
+				return requestRnr.Exec(ctx.Ctx, &executable.Executable{Request: &executable.RequestExecutableType{
+					Method:            executable.RequestExecutableTypeMethodPOST,
+					URL:               fixturesServer.URL + "/items",
+					Body:              body,
+					TransformResponse: "fromJSON(body).id",
+					LogResponse:       true,
+				}}, mockEngine, nil, nil)
+			}
+
+			It("should record requests and replay their responses offline", func() {
+				ctx.Logger.EXPECT().Info(gomock.Any(), gomock.Any(), "7").Times(2)
+				GinkgoT().Setenv(request.RecordEnv, fixturesDir)
+				Expect(exec(`{"name": "a"}`)).To(Succeed())
+
+				fixturesServer.Close()
+				GinkgoT().Setenv(request.RecordEnv, "")
+				GinkgoT().Setenv(request.ReplayEnv, fixturesDir)
+				Expect(exec(`{"name": "a"}`)).To(Succeed())
+			})
+
+			It("should fail when no fixture matches the request", func() {
+				ctx.Logger.EXPECT().Info(gomock.Any(), gomock.Any(), "7").Times(2)
+				GinkgoT().Setenv(request.RecordEnv, fixturesDir)
+				Expect(exec(`{"name": "a"}`)).To(Succeed())
+
+				GinkgoT().Setenv(request.RecordEnv, "")
+				GinkgoT().Setenv(request.ReplayEnv, fixturesDir)
+				Expect(exec(`{"name": "b"}`)).To(MatchError(ContainSubstring("no matching fixture for POST")))
+
+				GinkgoT().Setenv(request.ReplayMatchEnv, "method,url")
+				Expect(exec(`{"name": "b"}`)).To(Succeed())
+			})
+
+			It("should not record credentials", func() {
+				authServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if r.URL.Path == "/token" {
+						_, _ = w.Write([]byte(`{"access_token": "issued-token", "expires_in": 3600}`))
+						return
+					}
+					http.SetCookie(w, &http.Cookie{Name: "session", Value: "session-id"})
+					_, _ = w.Write([]byte(`{"id": 7}`))
+				}))
+				DeferCleanup(authServer.Close)
+				ctx.Logger.EXPECT().Infof(gomock.Any(), gomock.Any()).AnyTimes()
+				exec := func(auth *executable.RequestAuth) error {
+					return requestRnr.Exec(ctx.Ctx, &executable.Executable{Request: &executable.RequestExecutableType{
+						URL:  authServer.URL + "/items",
+						Auth: auth,
+					}}, mockEngine, nil, nil)
+				}
+				oauth2 := &executable.RequestAuth{Oauth2: &executable.RequestOAuth2Auth{
+					TokenURL:          authServer.URL + "/token",
+					ClientId:          "client",
+					ClientSecret:      "s3cret",
+					CredentialsInBody: true,
+				}}
+				apiKey := &executable.RequestAuth{ApiKey: &executable.RequestAPIKeyAuth{
+					Name: "api_key", Value: "key1", In: executable.RequestAPIKeyAuthInQuery,
+				}}
+
+				GinkgoT().Setenv(request.RecordEnv, fixturesDir)
+				Expect(exec(oauth2)).To(Succeed())
+				Expect(exec(apiKey)).To(Succeed())
+				entries, err := os.ReadDir(fixturesDir)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(entries).To(HaveLen(2))
+				for _, entry := range entries {
+					data, err := os.ReadFile(filepath.Join(fixturesDir, entry.Name()))
+					Expect(err).NotTo(HaveOccurred())
+					for _, secret := range []string{"s3cret", "issued-token", "session-id", "key1"} {
+						Expect(string(data)).NotTo(ContainSubstring(secret))
+					}
+				}
+
+				authServer.Close()
+				GinkgoT().Setenv(request.RecordEnv, "")
+				GinkgoT().Setenv(request.ReplayEnv, fixturesDir)
+				Expect(exec(oauth2)).To(Succeed())
+				Expect(exec(apiKey)).To(Succeed())
+			})
+
+			It("should fail when recording and replaying at the same time", func() {
+				GinkgoT().Setenv(request.RecordEnv, fixturesDir)
+				GinkgoT().Setenv(request.ReplayEnv, fixturesDir)
+				Expect(exec("")).To(MatchError(ContainSubstring("can't be recorded and replayed at the same time")))
+			})
+		})
 	})
 })
+
+func ptr[T any](v T) *T {
+	return &v
+}
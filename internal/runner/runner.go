@@ -79,6 +79,7 @@ func Reset() {
 type CtxData struct {
 	Workspace     string `expr:"workspace"`
 	Namespace     string `expr:"namespace"`
+	Profile       string `expr:"profile"`
 	WorkspacePath string `expr:"workspacePath"`
 	FlowFileName  string `expr:"flowFileName"`
 	FlowFilePath  string `expr:"flowFilePath"`
@@ -98,6 +99,7 @@ func ExpressionEnv(
 		"ctx", &CtxData{
 			Workspace:     ctx.CurrentWorkspaceName(),
 			Namespace:     ctx.Config.CurrentNamespace,
+			Profile:       ctx.ActiveProfile,
 			WorkspacePath: executable.WorkspacePath(),
 			FlowFileName:  fn,
 			FlowFilePath:  executable.FlowFilePath(),
@@ -190,6 +190,10 @@ func handleExec(
 			if exec.Request.ResponseFile != nil && serialSpec.Dir != "" && exec.Request.ResponseFile.Dir == "" {
 				exec.Request.ResponseFile.Dir = serialSpec.Dir
 			}
+		case exec.Graphql != nil:
+			if exec.Graphql.ResponseFile != nil && serialSpec.Dir != "" && exec.Graphql.ResponseFile.Dir == "" {
+				exec.Graphql.ResponseFile.Dir = serialSpec.Dir
+			}
 		case exec.Render != nil:
 			if serialSpec.Dir != "" && exec.Render.Dir == "" {
 				exec.Render.Dir = serialSpec.Dir
@@ -48,6 +48,161 @@ func ClonePath(gitURL string) (string, error) {
 	return filepath.Join(filesystem.CachedDataDirPath(), "git-workspaces", host, repoPath), nil
 }
 
+// CommitClonePath returns the content-addressed directory where a repository checkout of commit is cached:
+// ~/.cache/flow/git-commits/<commit>
+func CommitClonePath(commit string) string {
+	return filepath.Join(filesystem.CachedDataDirPath(), "git-commits", commit)
+}
+
+// resolvedRefPath returns the file that records the commit that a ref of a repository last resolved to:
+// ~/.cache/flow/git-commits/refs/<host>/<path>/<ref>
+func resolvedRefPath(gitURL, ref string) (string, error) {
+	host, repoPath, err := parseGitURL(gitURL)
+	if err != nil {
+		return "", err
+	}
+	repoPath = strings.TrimSuffix(repoPath, ".git")
+	repoPath = strings.TrimPrefix(repoPath, "/")
+	repoPath = strings.ReplaceAll(repoPath, ":", "_") // sanitize Windows drive-letter colons
+	return filepath.Join(filepath.Dir(CommitClonePath("x")), "refs", host, repoPath, url.PathEscape(ref)), nil
+}
+
+// ResolveRef returns the commit that ref (a tag or branch) of gitURL points to. Unless update is set, the commit
+// that the ref last resolved to is reused without reaching the remote, so resolved refs keep working offline. The
+// last resolved commit is also used when the remote can't be reached.
+func ResolveRef(gitURL, ref string, update bool) (string, error) {
+	if ref == "" {
+		return "", fmt.Errorf("a ref is required to resolve %s", gitURL)
+	}
+	recordPath, err := resolvedRefPath(gitURL, ref)
+	if err != nil {
+		return "", err
+	}
+	var recorded string
+	if data, err := os.ReadFile(filepath.Clean(recordPath)); err == nil {
+		recorded = strings.TrimSpace(string(data))
+	}
+	if recorded != "" && !update {
+		return recorded, nil
+	}
+
+	commit, err := lsRemote(gitURL, ref)
+	if err != nil {
+		if recorded != "" {
+			return recorded, nil
+		}
+		return "", err
+	}
+	if commit != recorded {
+		if err := os.MkdirAll(filepath.Dir(recordPath), 0750); err != nil {
+			return "", errors.Wrap(err, "unable to create git cache directory")
+		}
+		if err := os.WriteFile(recordPath, []byte(commit+"\n"), 0600); err != nil {
+			return "", errors.Wrap(err, "unable to record resolved git ref")
+		}
+	}
+	return commit, nil
+}
+
+// lsRemote returns the commit that a tag or branch of a remote repository points to. Annotated tags resolve to the
+// commit that they tag.
+func lsRemote(gitURL, ref string) (string, error) {
+	if err := EnsureInstalled(); err != nil {
+		return "", err
+	}
+	out, err := exec.Command("git", "ls-remote", gitURL, ref).Output() //nolint:gosec
+	if err != nil {
+		return "", errors.Wrapf(err, "git ls-remote %s %s", gitURL, ref)
+	}
+	commits := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if commit, name, found := strings.Cut(line, "\t"); found {
+			commits[name] = commit
+		}
+	}
+	for _, name := range []string{"refs/tags/" + ref + "^{}", "refs/tags/" + ref, "refs/heads/" + ref} {
+		if commit, found := commits[name]; found {
+			return commit, nil
+		}
+	}
+	return "", fmt.Errorf("ref %s not found in %s", ref, gitURL)
+}
+
+// EnsureCommitClone returns the cached checkout of gitURL at ref and the commit it resolved to. When commit is
+// set, that commit is checked out instead of ref, so the checkout doesn't change when ref is moved, and an existing
+// checkout of it is reused without fetching.
+func EnsureCommitClone(gitURL, ref, commit string) (string, string, error) {
+	if commit != "" {
+		if info, err := os.Stat(CommitClonePath(commit)); err == nil && info.IsDir() {
+			return CommitClonePath(commit), commit, nil
+		}
+	}
+
+	// Clone next to the target and move it into place so that an interrupted clone is never reused.
+	cacheDir := filepath.Dir(CommitClonePath("x"))
+	if err := os.MkdirAll(cacheDir, 0750); err != nil {
+		return "", "", errors.Wrap(err, "unable to create git cache directory")
+	}
+	tmpDir, err := os.MkdirTemp(cacheDir, ".clone-*")
+	if err != nil {
+		return "", "", errors.Wrap(err, "unable to create git cache directory")
+	}
+	defer os.RemoveAll(tmpDir)
+	resolved := commit
+	if commit != "" {
+		if err := fetchCommit(gitURL, tmpDir, commit); err != nil {
+			return "", "", errors.Wrapf(err, "unable to fetch commit %s of %s@%s, which is pinned", commit, gitURL, ref)
+		}
+	} else {
+		if err := Clone(gitURL, tmpDir, "", ref, 1); err != nil {
+			return "", "", err
+		}
+		if resolved, err = HeadCommit(tmpDir); err != nil {
+			return "", "", err
+		}
+	}
+
+	targetDir := CommitClonePath(resolved)
+	if _, err := os.Stat(targetDir); err == nil {
+		return targetDir, resolved, nil
+	}
+	if err := os.Rename(tmpDir, targetDir); err != nil {
+		return "", "", errors.Wrapf(err, "unable to move clone of %s into the cache", gitURL)
+	}
+	return targetDir, resolved, nil
+}
+
+// fetchCommit checks out commit of gitURL into the empty directory dir. Only the commit itself is fetched when the
+// server allows it; otherwise the repository's branches and tags are fetched to find it.
+func fetchCommit(gitURL, dir, commit string) error {
+	if err := runGit(dir, "init", "-q"); err != nil {
+		return err
+	}
+	if err := runGit(dir, "remote", "add", "origin", gitURL); err != nil {
+		return err
+	}
+	if err := runGit(dir, "fetch", "-q", "--depth", "1", "origin", commit); err != nil {
+		if err := runGit(dir, "fetch", "-q", "--tags", "origin"); err != nil {
+			return err
+		}
+	}
+	return runGit(dir, "-c", "advice.detachedHead=false", "checkout", "-q", commit)
+}
+
+// HeadCommit returns the commit checked out in a git repository.
+func HeadCommit(repoDir string) (string, error) {
+	if err := EnsureInstalled(); err != nil {
+		return "", err
+	}
+	cmd := exec.Command("git", "rev-parse", "HEAD")
+	cmd.Dir = repoDir
+	out, err := cmd.Output()
+	if err != nil {
+		return "", errors.Wrap(err, "git rev-parse HEAD")
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
 // Clone clones a git repository to the target directory.
 // If branch is non-empty, it checks out that branch.
 // If tag is non-empty, it checks out that tag.
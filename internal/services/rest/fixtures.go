@@ -0,0 +1,314 @@
+package rest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FixtureMode is how requests are handled when fixtures are configured.
+type FixtureMode string
+
+const (
+	// FixtureModeRecord sends requests and saves each request and its response as a fixture.
+	FixtureModeRecord FixtureMode = "record"
+	// FixtureModeReplay responds to requests with the response of a matching fixture instead of sending them.
+	FixtureModeReplay FixtureMode = "replay"
+)
+
+// MatchField is a part of a request that's compared when looking up the fixture for a request.
+type MatchField string
+
+const (
+	MatchMethod MatchField = "method"
+	MatchURL    MatchField = "url"
+	MatchBody   MatchField = "body"
+)
+
+// DefaultMatchFields are the fields that are compared when no fields are configured.
+var DefaultMatchFields = []MatchField{MatchMethod, MatchURL, MatchBody}
+
+var ErrNoMatchingFixture = errors.New("no matching fixture")
+
+const (
+	fixtureExt = ".yaml"
+	// redactedValue replaces the values of credentials in fixtures.
+	redactedValue = "REDACTED"
+)
+
+// sensitiveResponseHeaders are the response headers whose values are redacted in fixtures.
+var sensitiveResponseHeaders = []string{
+	"Authorization", "Proxy-Authorization", "Set-Cookie", "X-Api-Key", "X-Auth-Token", "X-Csrf-Token",
+}
+
+var fixtureNameChars = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+
+// Fixtures configures recording requests to, or replaying responses from, a directory of fixture files.
+type Fixtures struct {
+	Mode FixtureMode
+	Dir  string
+	// Match is the fields that a fixture's request must have in common with a request to be replayed for it. It
+	// defaults to DefaultMatchFields.
+	Match []MatchField
+	// RedactQuery is the query parameters whose values are redacted in fixtures (e.g. the parameter that an API key
+	// is sent in). Request URLs are redacted before they're matched, so fixtures still match the requests.
+	RedactQuery []string
+}
+
+// Fixture is a recorded request and its response. Since fixtures are meant to be committed, request headers aren't
+// recorded, and the values of sensitive response headers (e.g. Set-Cookie) and of redacted query parameters are
+// replaced.
+type Fixture struct {
+	Request  FixtureRequest  `yaml:"request"`
+	Response FixtureResponse `yaml:"response"`
+}
+
+type FixtureRequest struct {
+	Method string `yaml:"method"`
+	URL    string `yaml:"url"`
+	Body   string `yaml:"body,omitempty"`
+}
+
+type FixtureResponse struct {
+	Status  string      `yaml:"status"`
+	Code    int         `yaml:"code"`
+	Headers http.Header `yaml:"headers,omitempty"`
+	Body    string      `yaml:"body,omitempty"`
+}
+
+// ParseMatchFields parses a comma-separated list of match fields (e.g. "method,url").
+func ParseMatchFields(value string) ([]MatchField, error) {
+	var fields []MatchField
+	for _, f := range strings.Split(value, ",") {
+		field := MatchField(strings.ToLower(strings.TrimSpace(f)))
+		switch field {
+		case "":
+			continue
+		case MatchMethod, MatchURL, MatchBody:
+			fields = append(fields, field)
+		default:
+			return nil, fmt.Errorf("unknown fixture match field %q (must be method, url or body)", f)
+		}
+	}
+	return fields, nil
+}
+
+func (f *Fixtures) matchFields() []MatchField {
+	if len(f.Match) == 0 {
+		return DefaultMatchFields
+	}
+	return f.Match
+}
+
+// fixtureTransport records or replays the requests that are sent through it.
+type fixtureTransport struct {
+	fixtures *Fixtures
+	next     http.RoundTripper
+}
+
+func (t *fixtureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	fixtureReq := FixtureRequest{Method: req.Method, URL: t.fixtures.redactURL(req.URL), Body: body}
+
+	if t.fixtures.Mode == FixtureModeReplay {
+		fixture, err := t.fixtures.find(fixtureReq)
+		if err != nil {
+			return nil, err
+		}
+		return &http.Response{
+			Status:        fixture.Response.Status,
+			StatusCode:    fixture.Response.Code,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        fixture.Response.Headers.Clone(),
+			Body:          io.NopCloser(strings.NewReader(fixture.Response.Body)),
+			ContentLength: int64(len(fixture.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	fixture := &Fixture{
+		Request: fixtureReq,
+		Response: FixtureResponse{
+			Status:  resp.Status,
+			Code:    resp.StatusCode,
+			Headers: redactHeaders(resp.Header),
+			Body:    string(respBody),
+		},
+	}
+	if err := t.fixtures.save(fixture); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// find returns the first fixture, in file name order, whose request matches req.
+func (f *Fixtures) find(req FixtureRequest) (*Fixture, error) {
+	paths, err := filepath.Glob(filepath.Join(f.Dir, "*"+fixtureExt))
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(paths)
+	for _, path := range paths {
+		data, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return nil, fmt.Errorf("unable to read fixture %s: %w", path, err)
+		}
+		var fixture Fixture
+		if err := yaml.Unmarshal(data, &fixture); err != nil {
+			return nil, fmt.Errorf("unable to decode fixture %s: %w", path, err)
+		}
+		if f.matches(fixture.Request, req) {
+			return &fixture, nil
+		}
+	}
+	fields := make([]string, 0, len(f.matchFields()))
+	for _, field := range f.matchFields() {
+		fields = append(fields, string(field))
+	}
+	return nil, fmt.Errorf(
+		"%w for %s %s in %s (matching %s)", ErrNoMatchingFixture, req.Method, req.URL, f.Dir, strings.Join(fields, ", "),
+	)
+}
+
+func (f *Fixtures) matches(fixture, req FixtureRequest) bool {
+	for _, field := range f.matchFields() {
+		switch field {
+		case MatchMethod:
+			if !strings.EqualFold(fixture.Method, req.Method) {
+				return false
+			}
+		case MatchURL:
+			if normalizeURL(fixture.URL) != normalizeURL(req.URL) {
+				return false
+			}
+		case MatchBody:
+			if !bodiesEqual(fixture.Body, req.Body) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// save writes a fixture to a file that's named after its request, replacing the fixture of an identical request.
+func (f *Fixtures) save(fixture *Fixture) error {
+	if err := os.MkdirAll(f.Dir, 0750); err != nil {
+		return fmt.Errorf("unable to create fixtures directory: %w", err)
+	}
+	data, err := yaml.Marshal(fixture)
+	if err != nil {
+		return fmt.Errorf("unable to encode fixture: %w", err)
+	}
+	path := filepath.Join(f.Dir, fixtureName(fixture.Request))
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("unable to write fixture: %w", err)
+	}
+	return nil
+}
+
+// fixtureName returns the file name of a request's fixture, e.g. `get-api-example-com-users-1a2b3c4d.yaml`.
+func fixtureName(req FixtureRequest) string {
+	sum := sha256.Sum256([]byte(req.Method + "\n" + normalizeURL(req.URL) + "\n" + req.Body))
+	name := req.URL
+	if u, err := url.Parse(req.URL); err == nil {
+		name = u.Host + u.Path
+	}
+	name = strings.Trim(fixtureNameChars.ReplaceAllString(name, "-"), "-")
+	if len(name) > 64 {
+		name = name[:64]
+	}
+	return fmt.Sprintf("%s-%s-%s%s", strings.ToLower(req.Method), name, hex.EncodeToString(sum[:4]), fixtureExt)
+}
+
+// redactURL returns a URL with the values of the redacted query parameters replaced.
+func (f *Fixtures) redactURL(u *url.URL) string {
+	query := u.Query()
+	redacted := false
+	for _, name := range f.RedactQuery {
+		if query.Has(name) {
+			query.Set(name, redactedValue)
+			redacted = true
+		}
+	}
+	if !redacted {
+		return u.String()
+	}
+	redactedURL := *u
+	redactedURL.RawQuery = query.Encode()
+	return redactedURL.String()
+}
+
+// redactHeaders returns a copy of headers with the values of sensitive headers replaced.
+func redactHeaders(headers http.Header) http.Header {
+	redacted := headers.Clone()
+	for _, name := range sensitiveResponseHeaders {
+		if values := redacted.Values(name); len(values) > 0 {
+			redacted[http.CanonicalHeaderKey(name)] = slices.Repeat([]string{redactedValue}, len(values))
+		}
+	}
+	return redacted
+}
+
+// normalizeURL sorts the query parameters of a URL so that their order doesn't affect matching.
+func normalizeURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	u.RawQuery = u.Query().Encode()
+	return u.String()
+}
+
+// bodiesEqual compares JSON bodies by their values and other bodies as-is.
+func bodiesEqual(a, b string) bool {
+	if a == b {
+		return true
+	}
+	var aJSON, bJSON any
+	if json.Unmarshal([]byte(a), &aJSON) != nil || json.Unmarshal([]byte(b), &bJSON) != nil {
+		return false
+	}
+	return reflect.DeepEqual(aJSON, bJSON)
+}
+
+func readRequestBody(req *http.Request) (string, error) {
+	if req.Body == nil {
+		return "", nil
+	}
+	data, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return "", err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	return string(data), nil
+}
@@ -1,7 +1,9 @@
 package rest
 
 import (
+	"crypto/tls"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/url"
@@ -9,16 +11,48 @@ import (
 	"time"
 )
 
-const DefaultTimeout = 30 * time.Second
+const (
+	DefaultTimeout      = 30 * time.Second
+	DefaultMaxRedirects = 10
+)
 
 var ErrUnexpectedStatusCode = errors.New("unexpected status code")
 
+// StatusCodeError is returned when the status code of a response isn't accepted. It matches
+// ErrUnexpectedStatusCode with errors.Is and keeps the response, so that its body can be reported.
+type StatusCodeError struct {
+	Response *Response
+}
+
+func (e *StatusCodeError) Error() string {
+	return fmt.Sprintf("%s %s", ErrUnexpectedStatusCode, e.Response.Status)
+}
+
+func (e *StatusCodeError) Is(target error) bool {
+	return target == ErrUnexpectedStatusCode
+}
+
 type Request struct {
 	URL     string
 	Method  string
 	Headers map[string]string
 	Body    string
 	Timeout time.Duration
+	Client  ClientOptions
+}
+
+// ClientOptions configures the HTTP client that sends a request. The zero value uses the default transport,
+// with the proxy from the environment, and follows up to DefaultMaxRedirects redirects.
+type ClientOptions struct {
+	TLSConfig *tls.Config
+	ProxyURL  *url.URL
+	// DisableRedirects returns redirect responses instead of following them.
+	DisableRedirects bool
+	// MaxRedirects is the number of redirects that are followed. It defaults to DefaultMaxRedirects when it's 0, so
+	// DisableRedirects is the only way to not follow redirects.
+	MaxRedirects int
+	// Fixtures records the requests that are sent, or replays their responses, when it's set.
+	Fixtures *Fixtures
 }
 
 type Response struct {
@@ -30,7 +64,7 @@ type Response struct {
 
 func SendRequest(reqSpec *Request, validStatusCodes []int) (*Response, error) {
 	setRequestDefaults(reqSpec)
-	client := http.Client{Timeout: reqSpec.Timeout}
+	client := newClient(reqSpec)
 	reqURL, err := url.Parse(reqSpec.URL)
 	if err != nil {
 		return nil, err
@@ -47,6 +81,8 @@ func SendRequest(reqSpec *Request, validStatusCodes []int) (*Response, error) {
 	}
 	if reqSpec.Body != "" {
 		req.Body = io.NopCloser(strings.NewReader(reqSpec.Body))
+		// Servers may reject uploads that are sent without a length (chunked).
+		req.ContentLength = int64(len(reqSpec.Body))
 	}
 
 	httpResp, err := client.Do(&req)
@@ -55,10 +91,6 @@ func SendRequest(reqSpec *Request, validStatusCodes []int) (*Response, error) {
 	}
 	defer httpResp.Body.Close()
 
-	if !isStatusCodeAccepted(httpResp.StatusCode, validStatusCodes) {
-		return nil, ErrUnexpectedStatusCode
-	}
-
 	respBody, err := io.ReadAll(httpResp.Body)
 	if err != nil {
 		return nil, err
@@ -69,9 +101,49 @@ func SendRequest(reqSpec *Request, validStatusCodes []int) (*Response, error) {
 		Body:    string(respBody),
 		Headers: httpResp.Header,
 	}
+	if !isStatusCodeAccepted(httpResp.StatusCode, validStatusCodes) {
+		return nil, &StatusCodeError{Response: resp}
+	}
 	return resp, nil
 }
 
+func newClient(reqSpec *Request) *http.Client {
+	opts := reqSpec.Client
+	client := &http.Client{Timeout: reqSpec.Timeout}
+	if opts.TLSConfig != nil || opts.ProxyURL != nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		if opts.TLSConfig != nil {
+			transport.TLSClientConfig = opts.TLSConfig
+		}
+		if opts.ProxyURL != nil {
+			transport.Proxy = http.ProxyURL(opts.ProxyURL)
+		}
+		client.Transport = transport
+	}
+	if opts.Fixtures != nil {
+		next := client.Transport
+		if next == nil {
+			next = http.DefaultTransport
+		}
+		client.Transport = &fixtureTransport{fixtures: opts.Fixtures, next: next}
+	}
+	maxRedirects := opts.MaxRedirects
+	if maxRedirects == 0 {
+		maxRedirects = DefaultMaxRedirects
+	}
+	client.CheckRedirect = func(_ *http.Request, via []*http.Request) error {
+		if opts.DisableRedirects {
+			return http.ErrUseLastResponse
+		}
+		// via includes the original request, so it holds one request more than the redirects followed so far.
+		if len(via) > maxRedirects {
+			return fmt.Errorf("stopped after %d redirects", maxRedirects)
+		}
+		return nil
+	}
+	return client
+}
+
 func setRequestDefaults(req *Request) {
 	if req.Method == "" {
 		req.Method = "GET"
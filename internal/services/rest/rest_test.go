@@ -1,9 +1,11 @@
 package rest_test
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
 	"strconv"
 	"testing"
 	"time"
@@ -69,7 +71,10 @@ var _ = Describe("Rest", func() {
 				Timeout: 30 * time.Second,
 			}
 			_, err := rest.SendRequest(req, []int{http.StatusOK})
-			Expect(err).To(Equal(rest.ErrUnexpectedStatusCode))
+			Expect(err).To(MatchError(rest.ErrUnexpectedStatusCode))
+			var statusErr *rest.StatusCodeError
+			Expect(errors.As(err, &statusErr)).To(BeTrue())
+			Expect(statusErr.Response.Code).To(Equal(http.StatusInternalServerError))
 		})
 
 		It("should return the correct body when a valid request is made", func() {
@@ -106,4 +111,50 @@ var _ = Describe("Rest", func() {
 			Expect(resp.Body).To(ContainSubstring("\"Test-Header\": \"Test-Value\""))
 		})
 	})
+
+	Context("with fixtures", func() {
+		var dir string
+
+		BeforeEach(func() {
+			dir = GinkgoT().TempDir()
+		})
+
+		send := func(mode rest.FixtureMode, url, body string, match ...rest.MatchField) (*rest.Response, error) {
+			return rest.SendRequest(&rest.Request{
+				URL:    url,
+				Method: "POST",
+				Body:   body,
+				Client: rest.ClientOptions{Fixtures: &rest.Fixtures{Mode: mode, Dir: dir, Match: match}},
+			}, nil)
+		}
+
+		It("should replay recorded responses without sending requests", func() {
+			resp, err := send(rest.FixtureModeRecord, testServer.URL+"/items?b=2&a=1", `{"name": "flow", "n": 1}`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Body).To(Equal("success"))
+			files, err := filepath.Glob(filepath.Join(dir, "post-127-0-0-1-*-items-*.yaml"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files).To(HaveLen(1))
+
+			testServer.Close()
+			resp, err = send(rest.FixtureModeReplay, testServer.URL+"/items?a=1&b=2", `{"n": 1, "name": "flow"}`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(resp.Headers.Get("Content-Type")).To(Equal("application/json"))
+			Expect(resp.Body).To(Equal("success"))
+		})
+
+		It("should fail when no fixture matches", func() {
+			_, err := send(rest.FixtureModeRecord, testServer.URL+"/items", "a")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = send(rest.FixtureModeReplay, testServer.URL+"/items", "b")
+			Expect(err).To(MatchError(rest.ErrNoMatchingFixture))
+			Expect(err).To(MatchError(ContainSubstring("(matching method, url, body)")))
+
+			resp, err := send(rest.FixtureModeReplay, testServer.URL+"/items", "b", rest.MatchMethod, rest.MatchURL)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Body).To(Equal("success"))
+		})
+	})
 })
@@ -15,6 +15,10 @@ import (
 	"github.com/flowexec/flow/v2/types/executable"
 )
 
+// ProfileEnvKey is the environment variable used to expose the active workspace profile to executables.
+// When set on the flow process itself, it selects the profile to apply.
+const ProfileEnvKey = "FLOW_PROFILE"
+
 // SetEnv sets environment variables based on the parameters and arguments defined in the executable environment.
 //
 //nolint:gocognit
@@ -255,6 +259,9 @@ func DefaultEnv(ctx *context.Context, executable *executable.Executable) map[str
 	envMap["FLOW_RUNNER"] = "true"
 	envMap["FLOW_CURRENT_WORKSPACE"] = ctx.CurrentWorkspaceName()
 	envMap["FLOW_CURRENT_NAMESPACE"] = ctx.Config.CurrentNamespace
+	if ctx.ActiveProfile != "" {
+		envMap[ProfileEnvKey] = ctx.ActiveProfile
+	}
 	if ctx.ProcessTmpDir != "" {
 		envMap["FLOW_TMP_DIRECTORY"] = ctx.ProcessTmpDir
 	}
@@ -505,8 +505,23 @@ BUILD_ENV_VAR3=build_value3`
 			Expect(envMap["FLOW_CURRENT_NAMESPACE"]).To(Equal(nsName))
 			Expect(envMap["FLOW_EXECUTABLE_NAME"]).To(Equal(execName))
 			Expect(envMap[io.DisableInteractiveEnvKey]).To(Equal("true"))
+			Expect(envMap).NotTo(HaveKey(env.ProfileEnvKey))
 			// TODO: Add more assertions for other keys in the environment map
 		})
+
+		It("should include the active profile", func() {
+			ws := workspace.Workspace{}
+			ws.SetContext("test-workspace", "test-location")
+			ctx := &context.Context{
+				CurrentWorkspace: &ws,
+				Config:           &config.Config{},
+				ActiveProfile:    "staging",
+			}
+			exec := executable.Executable{Name: "test-executable"}
+			exec.SetContext("test-workspace", "test-location", "", "test-definition-location")
+			envMap := env.DefaultEnv(ctx, &exec)
+			Expect(envMap[env.ProfileEnvKey]).To(Equal("staging"))
+		})
 	})
 
 	Describe("BuildArgsFromEnv", func() {
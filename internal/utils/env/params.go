@@ -30,7 +30,7 @@ func ResolveParameterValue(
 		}
 		return val, nil
 	case param.SecretRef != "":
-		val, err := resolveSecretValue(currentVault, param.SecretRef)
+		val, err := ResolveSecretValue(currentVault, param.SecretRef)
 		if err != nil {
 			return "", fmt.Errorf("parameter %q: %w", param.EnvKey, err)
 		}
@@ -42,7 +42,9 @@ func ResolveParameterValue(
 	}
 }
 
-func resolveSecretValue(
+// ResolveSecretValue returns the plain text value of a secret reference, e.g. `my-secret` or `vault/my-secret`.
+// References without a vault are resolved from the current vault.
+func ResolveSecretValue(
 	currentVault string,
 	secretRef string,
 ) (string, error) {
@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"slices"
+	"strings"
 	"sync"
 
 	"github.com/pkg/errors"
@@ -45,6 +46,13 @@ type ExecutableCacheData struct {
 
 	loadedMu          sync.Mutex
 	loadedExecutables map[string]*executable.Executable
+
+	// extending holds the indexed executables that extend another executable. They are resolved once every
+	// workspace has been indexed, since the extended executable can belong to any of them.
+	extending executable.RefList
+	// fallback looks up extended executables that are not in this index, such as the persisted cache
+	// behind a discovered workspace's overlay.
+	fallback func(executable.Ref) (*executable.Executable, error)
 }
 
 type ExecutableCacheImpl struct {
@@ -76,8 +84,11 @@ func newExecutableCacheData() *ExecutableCacheData {
 //
 // This is shared by the persisted cache and the in-memory overlay built for a workspace
 // discovered from the working directory, so the two agree on visibility, validation, generated
-// imports, and alias expansion.
-func indexWorkspaceExecutables(data *ExecutableCacheData, wsCfg *workspace.Workspace) { //nolint:gocognit
+// imports, and alias expansion. syncImports resolves the refs of git imports again, which is only done when the
+// persisted cache is updated (`flow sync`); otherwise the commits they last resolved to are used.
+func indexWorkspaceExecutables( //nolint:gocognit
+	data *ExecutableCacheData, wsCfg *workspace.Workspace, syncImports bool,
+) {
 	name := wsCfg.AssignedName()
 	flowFiles, err := filesystem.LoadWorkspaceFlowFiles(wsCfg)
 	if err != nil {
@@ -86,7 +97,11 @@ func indexWorkspaceExecutables(data *ExecutableCacheData, wsCfg *workspace.Works
 	}
 	for _, flowFile := range flowFiles {
 		if len(flowFile.Imports) > 0 {
-			generated, err := fileparser.ExecutablesFromImports(name, flowFile)
+			importExecutables := fileparser.ExecutablesFromImports
+			if syncImports {
+				importExecutables = fileparser.SyncExecutablesFromImports
+			}
+			generated, err := importExecutables(name, flowFile)
 			if err != nil {
 				logger.Log().Error(
 					"failed to generate executables from files",
@@ -103,7 +118,13 @@ func indexWorkspaceExecutables(data *ExecutableCacheData, wsCfg *workspace.Works
 			continue
 		}
 		for _, e := range flowFile.Executables {
-			if vErr := e.Validate(); vErr != nil {
+			if e == nil || (e.Visibility != nil && common.Visibility(*e.Visibility).IsHidden()) {
+				continue
+			}
+			if e.Extends != "" {
+				// Validated with the extended executable's fields merged in; see resolveIndexedExtends.
+				data.extending = append(data.extending, e.Ref())
+			} else if vErr := e.Validate(); vErr != nil {
 				logger.Log().Warn(
 					"invalid executable found during cache update",
 					"ref", e.Ref().String(),
@@ -113,10 +134,6 @@ func indexWorkspaceExecutables(data *ExecutableCacheData, wsCfg *workspace.Works
 				continue
 			}
 
-			if e == nil || (e.Visibility != nil && common.Visibility(*e.Visibility).IsHidden()) {
-				continue
-			}
-
 			if existingPath, exists := data.ExecutableMap[e.Ref()]; exists && existingPath != flowFile.ConfigPath() {
 				logger.Log().Warn(
 					"duplicate executable found during cache update",
@@ -160,8 +177,9 @@ func (c *ExecutableCacheImpl) Update() error {
 	cacheData := newExecutableCacheData()
 	for name, wsCfg := range wsCacheData.Workspaces {
 		wsCfg.SetContext(name, wsCacheData.WorkspaceLocations[name])
-		indexWorkspaceExecutables(cacheData, wsCfg)
+		indexWorkspaceExecutables(cacheData, wsCfg, true)
 	}
+	resolveIndexedExtends(cacheData)
 
 	data, err := json.Marshal(cacheData)
 	if err != nil {
@@ -180,9 +198,79 @@ func (c *ExecutableCacheImpl) Update() error {
 	return nil
 }
 
+// resolveIndexedExtends resolves every indexed executable that extends another, removing those whose extended
+// executable is missing, not executable from their workspace, part of a cycle, or that are invalid once merged.
+// Removing one can break another that extends it, so this repeats until nothing else is removed.
+func resolveIndexedExtends(data *ExecutableCacheData) {
+	for removed := true; removed; {
+		removed = false
+		for _, ref := range data.extending {
+			if _, indexed := data.ExecutableMap[ref]; !indexed {
+				continue
+			}
+			if _, err := lookupExecutable(data, ref); err != nil {
+				logger.Log().Warn(
+					"unable to resolve extended executable during cache update",
+					"ref", ref.String(),
+					"err", err,
+				)
+				removeIndexedExecutable(data, ref)
+				removed = true
+			}
+		}
+		data.loadedMu.Lock()
+		data.loadedExecutables = nil
+		data.loadedMu.Unlock()
+	}
+	data.extending = nil
+}
+
+func removeIndexedExecutable(data *ExecutableCacheData, ref executable.Ref) {
+	delete(data.ExecutableMap, ref)
+	for alias, primary := range data.AliasMap {
+		if primary == ref {
+			delete(data.AliasMap, alias)
+		}
+	}
+}
+
+// resolveExtends returns e merged over the executable it extends. chain holds the refs already being resolved so
+// that cycles are reported instead of followed.
+func resolveExtends(
+	data *ExecutableCacheData, flowFile *executable.FlowFile, e *executable.Executable, chain executable.RefList,
+) (*executable.Executable, error) {
+	baseRef := e.ExtendsRef()
+	base, err := lookupExecutableChain(data, baseRef, chain)
+	var notFound flowErrors.ExecutableNotFoundError
+	if errors.As(err, &notFound) && data.fallback != nil {
+		base, err = data.fallback(baseRef)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to extend %s", baseRef)
+	}
+	if !base.IsExecutableFromWorkspace(e.Workspace()) {
+		return nil, errors.Errorf("unable to extend %s: not executable from workspace %s", baseRef, e.Workspace())
+	}
+
+	merged, err := e.Extend(base, flowFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to extend %s", baseRef)
+	}
+	if err := merged.Validate(); err != nil {
+		return nil, errors.Wrapf(err, "invalid executable after extending %s", baseRef)
+	}
+	return merged, nil
+}
+
 // lookupExecutable resolves ref against an index, following the alias map when the ref is not a
 // primary one, and loading the owning flow file to return the executable itself.
 func lookupExecutable(data *ExecutableCacheData, ref executable.Ref) (*executable.Executable, error) {
+	return lookupExecutableChain(data, ref, nil)
+}
+
+func lookupExecutableChain(
+	data *ExecutableCacheData, ref executable.Ref, chain executable.RefList,
+) (*executable.Executable, error) {
 	data.loadedMu.Lock()
 	if data.loadedExecutables == nil {
 		data.loadedExecutables = make(map[string]*executable.Executable)
@@ -205,6 +293,14 @@ func lookupExecutable(data *ExecutableCacheData, ref executable.Ref) (*executabl
 		}
 	}
 
+	if slices.Contains(chain, primaryRef) {
+		cycle := make([]string, 0, len(chain)+1)
+		for _, r := range append(chain, primaryRef) {
+			cycle = append(cycle, r.String())
+		}
+		return nil, errors.Errorf("extends cycle detected: %s", strings.Join(cycle, " -> "))
+	}
+
 	wsInfo, found := data.ConfigMap[cfgPath]
 	if !found {
 		return nil, errors.Errorf("unable to find workspace info for config %s", cfgPath)
@@ -215,13 +311,20 @@ func lookupExecutable(data *ExecutableCacheData, ref executable.Ref) (*executabl
 		return nil, err
 	}
 
-	exec, err := cfg.Executables.FindByVerbAndID(primaryRef.Verb(), primaryRef.ID())
+	exec, err := findIndexedExecutable(cfg, primaryRef)
 	if err != nil {
 		return nil, err
 	} else if exec == nil {
 		return nil, flowErrors.NewExecutableNotFoundError(ref.String())
 	}
 
+	if exec.Extends != "" {
+		exec, err = resolveExtends(data, cfg, exec, append(slices.Clone(chain), primaryRef))
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	data.loadedMu.Lock()
 	data.loadedExecutables[ref.String()] = exec
 	data.loadedMu.Unlock()
@@ -239,11 +342,36 @@ func listExecutables(data *ExecutableCacheData) executable.ExecutableList {
 			logger.Log().Error("unable to load executable config", "cfgPath", cfgPath, "err", err)
 			continue
 		}
-		list = append(list, cfg.Executables...)
+		for _, e := range cfg.Executables {
+			if e.Extends == "" {
+				list = append(list, e)
+				continue
+			} else if _, indexed := data.ExecutableMap[e.Ref()]; !indexed {
+				// Hidden, or dropped because it couldn't be resolved when the cache was updated.
+				continue
+			}
+			resolved, err := resolveExtends(data, cfg, e, executable.RefList{e.Ref()})
+			if err != nil {
+				logger.Log().Error("unable to resolve extended executable", "ref", e.Ref().String(), "err", err)
+				continue
+			}
+			list = append(list, resolved)
+		}
 	}
 	return list
 }
 
+// findIndexedExecutable returns the flow file's executable with the primary ref. Imports can give executables a
+// namespace other than the flow file's, so the ref is matched exactly before falling back to the verb and name.
+func findIndexedExecutable(cfg *executable.FlowFile, primaryRef executable.Ref) (*executable.Executable, error) {
+	for _, e := range cfg.Executables {
+		if e.Ref() == primaryRef {
+			return e, nil
+		}
+	}
+	return cfg.Executables.FindByVerbAndID(primaryRef.Verb(), primaryRef.ID())
+}
+
 // loadFlowFileWithImports reads a flow file, attaches its workspace context, and appends the
 // executables generated from its imports.
 func loadFlowFileWithImports(cfgPath string, wsInfo WorkspaceInfo) (*executable.FlowFile, error) {
@@ -514,6 +514,111 @@ var _ = Describe("ExecutableCacheImpl", func() {
 		})
 	})
 
+	Describe("Executable extends", func() {
+		writeFlowFile := func(name string, execs executable.ExecutableList) {
+			v := executable.FlowFileVisibility(common.VisibilityPrivate)
+			execCfg := &executable.FlowFile{Namespace: "testdata", Visibility: &v, Executables: execs}
+			execCfg.SetContext(wsName, wsPath, filepath.Join(wsPath, name+executable.FlowFileExt))
+			Expect(filesystem.WriteFlowFile(execCfg.ConfigPath(), execCfg)).To(Succeed())
+		}
+
+		BeforeEach(func() {
+			mockLogger.EXPECT().Debugf(gomock.Any()).AnyTimes()
+			mockLogger.EXPECT().Debug(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+		})
+
+		It("should merge the extended executable into the extending one", func() {
+			writeFlowFile("base", executable.ExecutableList{
+				{
+					Verb: "deploy", Name: "base", Tags: []string{"k8s"},
+					Exec: &executable.ExecExecutableType{
+						Cmd: "kubectl apply -f $MANIFEST",
+						Params: executable.ParameterList{
+							{EnvKey: "MANIFEST", Text: "base.yaml"},
+							{EnvKey: "CONTEXT", Text: "dev"},
+						},
+					},
+				},
+			})
+			writeFlowFile("prod", executable.ExecutableList{
+				{
+					Verb: "deploy", Name: "prod", Extends: "deploy testdata:base",
+					Exec: &executable.ExecExecutableType{
+						Params: executable.ParameterList{{EnvKey: "CONTEXT", Text: "prod"}},
+					},
+				},
+			})
+			Expect(execCache.Update()).To(Succeed())
+
+			e, err := execCache.GetExecutableByRef("deploy test/testdata:prod")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(e.Name).To(Equal("prod"))
+			Expect(e.Tags).To(ConsistOf("k8s"))
+			Expect(e.Exec.Cmd).To(Equal("kubectl apply -f $MANIFEST"))
+			Expect(e.Exec.Params).To(ConsistOf(
+				executable.Parameter{EnvKey: "MANIFEST", Text: "base.yaml"},
+				executable.Parameter{EnvKey: "CONTEXT", Text: "prod"},
+			))
+
+			list, err := execCache.GetExecutableList()
+			Expect(err).NotTo(HaveOccurred())
+			prod := list.FilterByWorkspace(wsName).FilterByNamespace("testdata")
+			Expect(prod).To(ContainElement(HaveField("Exec.Cmd", "kubectl apply -f $MANIFEST")))
+		})
+
+		It("should drop executables that are part of an extends cycle", func() {
+			writeFlowFile("cycle", executable.ExecutableList{
+				{Verb: "run", Name: "a", Extends: "run testdata:b", Exec: &executable.ExecExecutableType{Cmd: "echo a"}},
+				{Verb: "run", Name: "b", Extends: "run testdata:a", Exec: &executable.ExecExecutableType{Cmd: "echo b"}},
+			})
+			mockLogger.EXPECT().Warn(
+				"unable to resolve extended executable during cache update",
+				"ref", gomock.Any(), "err", gomock.Any(),
+			).Times(2)
+			Expect(execCache.Update()).To(Succeed())
+
+			_, err := execCache.GetExecutableByRef("run test/testdata:a")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("executable not found"))
+		})
+
+		It("should not extend executables that are not executable from the workspace", func() {
+			otherName, otherPath := "other", filepath.Join(cacheDir, "other")
+			Expect(filesystem.InitWorkspaceConfig(otherName, otherPath)).To(Succeed())
+			otherCfg, err := filesystem.LoadWorkspaceConfig(otherName, otherPath)
+			Expect(err).NotTo(HaveOccurred())
+			v := executable.FlowFileVisibility(common.VisibilityPrivate)
+			otherFlowFile := &executable.FlowFile{
+				Visibility: &v,
+				Executables: executable.ExecutableList{
+					{Verb: "run", Name: "secret", Exec: &executable.ExecExecutableType{Cmd: "echo secret"}},
+				},
+			}
+			otherFlowFile.SetContext(otherName, otherPath, filepath.Join(otherPath, "other"+executable.FlowFileExt))
+			Expect(filesystem.WriteFlowFile(otherFlowFile.ConfigPath(), otherFlowFile)).To(Succeed())
+			writeFlowFile("extends", executable.ExecutableList{
+				{Verb: "run", Name: "copy", Extends: "run other/secret", Exec: &executable.ExecExecutableType{}},
+			})
+
+			wsConfig, err := filesystem.LoadWorkspaceConfig(wsName, wsPath)
+			Expect(err).NotTo(HaveOccurred())
+			wsCache = cacheMocks.NewMockWorkspaceCache(gomock.NewController(GinkgoT()))
+			wsCache.EXPECT().GetLatestData().Return(&cache.WorkspaceCacheData{
+				Workspaces:         map[string]*workspace.Workspace{wsName: wsConfig, otherName: otherCfg},
+				WorkspaceLocations: map[string]string{wsName: wsPath, otherName: otherPath},
+			}, nil).AnyTimes()
+			execCache.WorkspaceCache = wsCache
+			mockLogger.EXPECT().Warn(
+				"unable to resolve extended executable during cache update",
+				"ref", "run test/testdata:copy", "err", gomock.Any(),
+			).Times(1)
+			Expect(execCache.Update()).To(Succeed())
+
+			_, err = execCache.GetExecutableByRef("run test/testdata:copy")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
 	Describe("Concurrent access", func() {
 		It("resolves refs correctly under concurrent lookups", func() {
 			// Mirrors nested parallel/serial executables, whose sub-refs are resolved from
@@ -103,7 +103,9 @@ func (c *localExecutableCache) index() *ExecutableCacheData {
 	defer c.mu.Unlock()
 	if c.data == nil {
 		c.data = newExecutableCacheData()
-		indexWorkspaceExecutables(c.data, c.ws)
+		c.data.fallback = c.base.GetExecutableByRef
+		indexWorkspaceExecutables(c.data, c.ws, false)
+		resolveIndexedExtends(c.data)
 	}
 	return c.data
 }
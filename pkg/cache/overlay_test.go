@@ -3,6 +3,7 @@ package cache_test
 import (
 	"encoding/json"
 	"os"
+	"os/exec"
 	"path/filepath"
 
 	"github.com/flowexec/tuikit/io/mocks"
@@ -143,6 +144,55 @@ var _ = Describe("Local workspace overlay", func() {
 		})
 	})
 
+	Describe("git imports", func() {
+		It("only resolves branch refs again when the persisted cache is updated", func() {
+			if _, err := exec.LookPath("git"); err != nil {
+				Skip("git is not installed")
+			}
+			repoDir := filepath.Join(tmpDir, "shared.git")
+			Expect(os.MkdirAll(repoDir, 0750)).To(Succeed())
+			git := func(args ...string) {
+				cmd := exec.Command("git", args...)
+				cmd.Dir = repoDir
+				out, err := cmd.CombinedOutput()
+				Expect(err).NotTo(HaveOccurred(), string(out))
+			}
+			writeShared := func(names ...string) {
+				shared := &executable.FlowFile{}
+				for _, name := range names {
+					shared.Executables = append(shared.Executables,
+						&executable.Executable{Verb: "run", Name: name, Exec: &executable.ExecExecutableType{}})
+				}
+				Expect(filesystem.WriteFlowFile(filepath.Join(repoDir, "shared.yaml"), shared)).To(Succeed())
+				git("add", ".")
+				git("-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "update")
+			}
+			git("init", "-q", "-b", "main")
+			writeShared("one")
+
+			for _, wsCfg := range []*workspace.Workspace{registeredWs, localWs} {
+				path := filepath.Join(wsCfg.Location(), "test"+executable.FlowFileExt)
+				flowFile, err := filesystem.LoadFlowFile(path)
+				Expect(err).NotTo(HaveOccurred())
+				flowFile.Imports = executable.Imports{"file://" + repoDir + "//shared.yaml?ref=main"}
+				Expect(filesystem.WriteFlowFile(path, flowFile)).To(Succeed())
+			}
+			Expect(baseExecCache.Update()).To(Succeed())
+			_, err := cache.NewLocalExecutableCache(baseExecCache, localWs).
+				GetExecutableByRef(executable.NewRef("worktree/ns:one", "run"))
+			Expect(err).NotTo(HaveOccurred())
+
+			writeShared("one", "two")
+			_, err = cache.NewLocalExecutableCache(baseExecCache, localWs).
+				GetExecutableByRef(executable.NewRef("worktree/ns:two", "run"))
+			Expect(err).To(HaveOccurred())
+
+			Expect(baseExecCache.Update()).To(Succeed())
+			_, err = baseExecCache.GetExecutableByRef(executable.NewRef("registered/ns:two", "run"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
 	Describe("persistence", func() {
 		It("never writes the discovered workspace into the data store", func() {
 			overlay := cache.NewLocalExecutableCache(baseExecCache, localWs)
@@ -0,0 +1,137 @@
+package cache
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/flowexec/flow/v2/internal/services/git"
+	"github.com/flowexec/flow/v2/pkg/filesystem"
+	"github.com/flowexec/flow/v2/pkg/logger"
+	"github.com/flowexec/flow/v2/types/executable"
+	"github.com/flowexec/flow/v2/types/workspace"
+)
+
+// The remote cache resolves references to executables in a git repository at a version
+// (`exec github.com/org/tools@v1.2.0/k8s:deploy`) without the repository being registered as a workspace.
+//
+// Each repository version is resolved to a commit and cloned once into the content-addressed directory under the
+// flow cache that git imports use as well. The commit is pinned in the current workspace's lockfile on first use, so
+// later runs use the same code and don't need the network. Like the overlay caches, the remote indexes are only kept
+// in memory; nothing is written to the data store.
+
+// NewRemoteExecutableCache resolves remote workspace references itself and everything else with base. ws is the
+// workspace whose lockfile pins the remote versions; without one, remote versions use the commit that they last
+// resolved to.
+func NewRemoteExecutableCache(base ExecutableCache, ws *workspace.Workspace) ExecutableCache {
+	return &remoteExecutableCache{base: base, ws: ws}
+}
+
+type remoteExecutableCache struct {
+	base ExecutableCache
+	ws   *workspace.Workspace
+
+	mu   sync.Mutex
+	data map[string]*ExecutableCacheData
+}
+
+func (c *remoteExecutableCache) Update() error {
+	c.mu.Lock()
+	c.data = nil
+	c.mu.Unlock()
+	return c.base.Update()
+}
+
+func (c *remoteExecutableCache) GetExecutableByRef(ref executable.Ref) (*executable.Executable, error) {
+	wsName := ref.Workspace()
+	if !executable.IsRemoteWorkspace(wsName) {
+		return c.base.GetExecutableByRef(ref)
+	}
+	data, err := c.index(wsName)
+	if err != nil {
+		return nil, err
+	}
+	return lookupExecutable(data, ref)
+}
+
+// GetExecutableList returns the executables of the base cache. Remote executables aren't listed, since the remote
+// versions are only known once they're referenced.
+func (c *remoteExecutableCache) GetExecutableList() (executable.ExecutableList, error) {
+	return c.base.GetExecutableList()
+}
+
+// index returns the executable index of a remote workspace, fetching and pinning it on first use.
+func (c *remoteExecutableCache) index(wsName string) (*ExecutableCacheData, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if data, found := c.data[wsName]; found {
+		return data, nil
+	}
+
+	path, err := c.checkout(wsName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to fetch remote workspace %s", wsName)
+	}
+	var wsCfg *workspace.Workspace
+	if filesystem.WorkspaceConfigExists(path) {
+		wsCfg, err = filesystem.ReadWorkspaceConfig(wsName, path)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		wsCfg = workspace.DefaultWorkspaceConfig(wsName)
+		wsCfg.SetContext(wsName, path)
+	}
+
+	data := newExecutableCacheData()
+	indexWorkspaceExecutables(data, wsCfg, false)
+	resolveIndexedExtends(data)
+	if c.data == nil {
+		c.data = make(map[string]*ExecutableCacheData)
+	}
+	c.data[wsName] = data
+	return data, nil
+}
+
+// checkout returns the local checkout of a remote workspace, pinning the commit it resolved to in the current
+// workspace's lockfile.
+func (c *remoteExecutableCache) checkout(wsName string) (string, error) {
+	repo, version, _ := executable.ParseRemoteWorkspace(wsName)
+	gitURL := executable.RemoteGitURL(repo)
+	if c.ws == nil {
+		commit, err := git.ResolveRef(gitURL, version, false)
+		if err != nil {
+			return "", err
+		}
+		path, _, err := git.EnsureCommitClone(gitURL, version, commit)
+		return path, err
+	}
+
+	lock, err := filesystem.LoadLockfile(c.ws.Location())
+	if err != nil {
+		return "", err
+	}
+	var pinned string
+	locked, found := lock.Remotes[wsName]
+	if found && locked.URL == gitURL {
+		pinned = locked.Commit
+	}
+	commit := pinned
+	if commit == "" {
+		if commit, err = git.ResolveRef(gitURL, version, true); err != nil {
+			return "", err
+		}
+	}
+	path, _, err := git.EnsureCommitClone(gitURL, version, commit)
+	if err != nil {
+		return "", err
+	}
+	if pinned == "" {
+		lock.Remotes[wsName] = filesystem.LockedRemote{URL: gitURL, Commit: commit}
+		if err := filesystem.WriteLockfile(c.ws.Location(), lock); err != nil {
+			return "", err
+		}
+		logger.Log().Debug("pinned remote workspace", "workspace", wsName, "commit", commit)
+	}
+	return path, nil
+}
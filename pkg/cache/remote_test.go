@@ -0,0 +1,135 @@
+package cache_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/flowexec/tuikit/io/mocks"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/flowexec/flow/v2/pkg/cache"
+	cacheMocks "github.com/flowexec/flow/v2/pkg/cache/mocks"
+	"github.com/flowexec/flow/v2/pkg/filesystem"
+	"github.com/flowexec/flow/v2/pkg/logger"
+	"github.com/flowexec/flow/v2/types/common"
+	"github.com/flowexec/flow/v2/types/executable"
+	"github.com/flowexec/flow/v2/types/workspace"
+)
+
+var _ = Describe("Remote workspace executables", func() {
+	var (
+		tmpDir, repoURL string
+		baseExecCache   *cacheMocks.MockExecutableCache
+		localWs         *workspace.Workspace
+	)
+
+	git := func(dir string, args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		Expect(err).NotTo(HaveOccurred(), string(out))
+	}
+
+	BeforeEach(func() {
+		if _, err := exec.LookPath("git"); err != nil {
+			Skip("git is not installed")
+		}
+		mockLogger := mocks.NewMockLogger(gomock.NewController(GinkgoT()))
+		logger.Init(logger.InitOptions{Logger: mockLogger, TestingTB: GinkgoTB()})
+		mockLogger.EXPECT().Debugf(gomock.Any()).AnyTimes()
+		mockLogger.EXPECT().Debug(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+		mockLogger.EXPECT().Debug(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+		tmpDir = GinkgoT().TempDir()
+		GinkgoT().Setenv(filesystem.FlowCacheDirEnvVar, filepath.Join(tmpDir, "cache"))
+
+		repoDir := filepath.Join(tmpDir, "tools")
+		Expect(os.MkdirAll(repoDir, 0750)).To(Succeed())
+		v := executable.FlowFileVisibility(common.VisibilityPublic)
+		flowFile := &executable.FlowFile{
+			Namespace:  "k8s",
+			Visibility: &v,
+			Executables: executable.ExecutableList{
+				{Verb: "deploy", Name: "app", Exec: &executable.ExecExecutableType{Cmd: "kubectl apply -f ."}},
+			},
+		}
+		Expect(filesystem.WriteFlowFile(filepath.Join(repoDir, "k8s"+executable.FlowFileExt), flowFile)).To(Succeed())
+		git(repoDir, "init", "-q")
+		git(repoDir, "add", ".")
+		git(repoDir, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "init")
+		git(repoDir, "tag", "v1.0.0")
+		repoURL = "file://" + repoDir
+
+		localWs = workspace.DefaultWorkspaceConfig("local")
+		localWs.SetContext("local", filepath.Join(tmpDir, "local"))
+		Expect(os.MkdirAll(localWs.Location(), 0750)).To(Succeed())
+		baseExecCache = cacheMocks.NewMockExecutableCache(gomock.NewController(GinkgoT()))
+	})
+
+	It("resolves and pins executables from a git repository version", func() {
+		remoteCache := cache.NewRemoteExecutableCache(baseExecCache, localWs)
+		ref := executable.Ref("deploy " + repoURL + "@v1.0.0/k8s:app")
+		e, err := remoteCache.GetExecutableByRef(ref)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(e.Exec.Cmd).To(Equal("kubectl apply -f ."))
+		Expect(e.Ref()).To(Equal(ref))
+
+		lock, err := filesystem.LoadLockfile(localWs.Location())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lock.Remotes).To(HaveKey(repoURL + "@v1.0.0"))
+		Expect(lock.Remotes[repoURL+"@v1.0.0"].Commit).NotTo(BeEmpty())
+
+		// Once pinned and fetched, the repository is no longer needed.
+		Expect(os.RemoveAll(filepath.Join(tmpDir, "tools"))).To(Succeed())
+		e, err = cache.NewRemoteExecutableCache(baseExecCache, localWs).GetExecutableByRef(ref)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(e.Name).To(Equal("app"))
+	})
+
+	It("falls back to the base cache for other references", func() {
+		ref := executable.Ref("run local/ns:build")
+		baseExecCache.EXPECT().GetExecutableByRef(ref).Return(&executable.Executable{Name: "build"}, nil)
+		e, err := cache.NewRemoteExecutableCache(baseExecCache, localWs).GetExecutableByRef(ref)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(e.Name).To(Equal("build"))
+	})
+
+	It("fails when the pinned commit can't be fetched", func() {
+		lock := &filesystem.Lockfile{Remotes: map[string]filesystem.LockedRemote{
+			repoURL + "@v1.0.0": {URL: repoURL, Commit: "0000000000000000000000000000000000000000"},
+		}}
+		Expect(filesystem.WriteLockfile(localWs.Location(), lock)).To(Succeed())
+		_, err := cache.NewRemoteExecutableCache(baseExecCache, localWs).
+			GetExecutableByRef(executable.Ref("deploy " + repoURL + "@v1.0.0/k8s:app"))
+		Expect(err).To(MatchError(ContainSubstring("which is pinned")))
+	})
+
+	It("checks out the pinned commit after the version has moved", func() {
+		repoDir := filepath.Join(tmpDir, "tools")
+		git(repoDir, "branch", "stable")
+		ref := executable.Ref("deploy " + repoURL + "@stable/k8s:app")
+		_, err := cache.NewRemoteExecutableCache(baseExecCache, localWs).GetExecutableByRef(ref)
+		Expect(err).NotTo(HaveOccurred())
+
+		// Move the branch and start from an empty flow cache, like on another machine that shares the lockfile.
+		v := executable.FlowFileVisibility(common.VisibilityPublic)
+		flowFile := &executable.FlowFile{
+			Namespace:  "k8s",
+			Visibility: &v,
+			Executables: executable.ExecutableList{
+				{Verb: "deploy", Name: "app", Exec: &executable.ExecExecutableType{Cmd: "helm upgrade"}},
+			},
+		}
+		Expect(filesystem.WriteFlowFile(filepath.Join(repoDir, "k8s"+executable.FlowFileExt), flowFile)).To(Succeed())
+		git(repoDir, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-am", "helm")
+		git(repoDir, "branch", "-f", "stable", "HEAD")
+		Expect(os.RemoveAll(filepath.Join(tmpDir, "cache"))).To(Succeed())
+
+		e, err := cache.NewRemoteExecutableCache(baseExecCache, localWs).GetExecutableByRef(ref)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(e.Exec.Cmd).To(Equal("kubectl apply -f ."))
+	})
+})
@@ -50,6 +50,10 @@ type Context struct {
 	// This will be nil if the context is not associated with an executable run.
 	RootExecutable *executable.Executable
 
+	// ActiveProfile is the name of the workspace environment profile applied to the current run.
+	// This will be empty if no profile is active.
+	ActiveProfile string
+
 	// ProcessTmpDir is the temporary directory for the current process. If set, it will be
 	// used to store temporary files all executable runs when the tmpDir value is specified.
 	ProcessTmpDir string
@@ -118,6 +122,7 @@ func NewContext(ctx context.Context, cancelFunc context.CancelFunc, opts ...Opti
 		executableCache = cache.NewLocalExecutableCache(executableCache, wsConfig)
 		templateCache = cache.NewLocalTemplateCache(templateCache, wsConfig)
 	}
+	executableCache = cache.NewRemoteExecutableCache(executableCache, wsConfig)
 
 	c := &Context{
 		appName:             "flow",
@@ -428,6 +433,7 @@ func (ctx *Context) SetCurrentWorkspace(resolved *filesystem.ResolvedWorkspace)
 		execCache = cache.NewLocalExecutableCache(execCache, resolved.Workspace)
 		tmplCache = cache.NewLocalTemplateCache(tmplCache, resolved.Workspace)
 	}
+	execCache = cache.NewRemoteExecutableCache(execCache, resolved.Workspace)
 	ctx.WorkspacesCache = wsCache
 	ctx.ExecutableCache = execCache
 	ctx.TemplateCache = tmplCache
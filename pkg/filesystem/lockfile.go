@@ -0,0 +1,53 @@
+package filesystem
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+const LockfileName = "flow.lock"
+
+// Lockfile pins the remote workspaces referenced from a workspace to the commits they first resolved to, so that
+// later runs use the same code (and don't need the network once it has been fetched).
+type Lockfile struct {
+	Remotes map[string]LockedRemote `yaml:"remotes"`
+}
+
+// LockedRemote is a remote workspace (<repo>@<version>) pinned to a commit of its git repository.
+type LockedRemote struct {
+	URL    string `yaml:"url"`
+	Commit string `yaml:"commit"`
+}
+
+// LoadLockfile reads the workspace's lockfile. A missing lockfile is returned as an empty one.
+func LoadLockfile(workspacePath string) (*Lockfile, error) {
+	lock := &Lockfile{Remotes: make(map[string]LockedRemote)}
+	data, err := os.ReadFile(filepath.Clean(filepath.Join(workspacePath, LockfileName)))
+	if errors.Is(err, os.ErrNotExist) {
+		return lock, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "unable to read lockfile")
+	}
+	if err := yaml.Unmarshal(data, lock); err != nil {
+		return nil, errors.Wrap(err, "unable to decode lockfile")
+	}
+	if lock.Remotes == nil {
+		lock.Remotes = make(map[string]LockedRemote)
+	}
+	return lock, nil
+}
+
+func WriteLockfile(workspacePath string, lock *Lockfile) error {
+	data, err := yaml.Marshal(lock)
+	if err != nil {
+		return errors.Wrap(err, "unable to encode lockfile")
+	}
+	header := []byte("# Generated by flow. Pins remote executable references to git commits.\n")
+	if err := os.WriteFile(filepath.Join(workspacePath, LockfileName), append(header, data...), 0644); err != nil { //nolint:gosec // shared in version control
+		return errors.Wrap(err, "unable to write lockfile")
+	}
+	return nil
+}
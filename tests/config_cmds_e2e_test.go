@@ -50,6 +50,15 @@ var _ = Describe("config e2e", Ordered, func() {
 		})
 	})
 
+	When("setting profile (flow config set profile)", func() {
+		It("should set the profile successfully", func() {
+			Expect(run.Run(ctx.Context, "config", "set", "profile", "staging")).To(Succeed())
+			out, err := readFileContent(ctx.StdOut())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(ContainSubstring("Profile set to staging"))
+		})
+	})
+
 	When("setting workspace mode (flow config set workspace-mode)", func() {
 		It("should set workspace mode to fixed", func() {
 			Expect(run.Run(ctx.Context, "config", "set", "workspace-mode", "fixed")).To(Succeed())
@@ -10,7 +10,9 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/flowexec/flow/v2/pkg/filesystem"
 	"github.com/flowexec/flow/v2/tests/utils"
+	"github.com/flowexec/flow/v2/types/workspace"
 )
 
 var _ = Describe("exec e2e", func() {
@@ -100,4 +102,33 @@ var _ = Describe("exec e2e", func() {
 			Expect(out).To(ContainSubstring("flow completed"))
 		})
 	})
+	Describe("workspace profiles", func() {
+		BeforeEach(func() {
+			wsCfg, err := filesystem.LoadWorkspaceConfig(utils.TestWorkspaceName, ctx.WorkspaceDir())
+			Expect(err).ToNot(HaveOccurred())
+			wsCfg.Profiles = workspace.WorkspaceProfiles{
+				"staging": {Params: map[string]string{"WORKSPACE_ENV_VAR": "value_from_staging"}},
+			}
+			Expect(filesystem.WriteWorkspaceConfig(ctx.WorkspaceDir(), wsCfg)).To(Succeed())
+			Expect(ctx.WorkspacesCache.Update()).To(Succeed())
+		})
+
+		It("should apply the params of the selected profile", func() {
+			runner := utils.NewE2ECommandRunner()
+			stdOut := ctx.StdOut()
+			Expect(runner.Run(
+				ctx.Context, "exec", "examples:with-workspace-env", "--profile", "staging",
+			)).To(Succeed())
+			out, _ := readFileContent(stdOut)
+			Expect(out).To(ContainSubstring("WORKSPACE_ENV_VAR=value_from_staging"))
+		})
+
+		It("fatals when the selected profile is not defined", func() {
+			runner := utils.NewE2ECommandRunner()
+			ctx.ExpectFailure()
+			err := runner.Run(ctx.Context, "exec", "examples:with-workspace-env", "--profile", "prod")
+			Expect(err).To(HaveOccurred())
+			Expect(ctx.ExitCalls()).NotTo(BeEmpty())
+		})
+	})
 })
@@ -13,6 +13,56 @@ type Aliases []string
 // collisions between tools.
 type Annotations map[string]string
 
+// Settings of the HTTP client used by requests.
+type RequestClient struct {
+	// If set to false, redirect responses are returned instead of followed. Redirects
+	// are followed by default.
+	FollowRedirects *bool `json:"followRedirects,omitempty" yaml:"followRedirects,omitempty" mapstructure:"followRedirects,omitempty"`
+
+	// The maximum number of redirects that are followed. Defaults to 10. Setting it
+	// to 0 returns redirect
+	// responses instead of following them, like `followRedirects: false`.
+	//
+	MaxRedirects *int `json:"maxRedirects,omitempty" yaml:"maxRedirects,omitempty" mapstructure:"maxRedirects,omitempty"`
+
+	// The URL of the proxy used for requests (e.g. `http://proxy.example.com:8080`).
+	// If not set, the `HTTP_PROXY`, `HTTPS_PROXY` and `NO_PROXY` environment
+	// variables are used.
+	//
+	Proxy string `json:"proxy,omitempty" yaml:"proxy,omitempty" mapstructure:"proxy,omitempty"`
+
+	// Tls corresponds to the JSON schema field "tls".
+	Tls *RequestTLS `json:"tls,omitempty" yaml:"tls,omitempty" mapstructure:"tls,omitempty"`
+}
+
+// TLS settings for HTTP requests. Paths are relative to the flowfile of the
+// executable, or to the workspace root
+// when they're set in the workspace's request defaults or start with `//`.
+type RequestTLS struct {
+	// Path to a PEM bundle of CA certificates trusted in addition to the system's.
+	CaFile string `json:"caFile,omitempty" yaml:"caFile,omitempty" mapstructure:"caFile,omitempty"`
+
+	// Path to the PEM client certificate used for mutual TLS.
+	CertFile string `json:"certFile,omitempty" yaml:"certFile,omitempty" mapstructure:"certFile,omitempty"`
+
+	// A reference to a vault secret with the PEM client certificate. Used instead of
+	// `certFile`.
+	CertSecretRef string `json:"certSecretRef,omitempty" yaml:"certSecretRef,omitempty" mapstructure:"certSecretRef,omitempty"`
+
+	// If set to true, the server's certificate is not verified. A warning is logged
+	// for every request.
+	// This should only be used for testing.
+	//
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty" yaml:"insecureSkipVerify,omitempty" mapstructure:"insecureSkipVerify,omitempty"`
+
+	// Path to the PEM private key of the client certificate.
+	KeyFile string `json:"keyFile,omitempty" yaml:"keyFile,omitempty" mapstructure:"keyFile,omitempty"`
+
+	// A reference to a vault secret with the PEM private key. Used instead of
+	// `keyFile`.
+	KeySecretRef string `json:"keySecretRef,omitempty" yaml:"keySecretRef,omitempty" mapstructure:"keySecretRef,omitempty"`
+}
+
 // A list of tags.
 // Tags can be used with list commands to filter returned data.
 type Tags []string
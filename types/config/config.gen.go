@@ -22,7 +22,6 @@ type ColorPalette struct {
 	// See [chroma styles](https://github.com/alecthomas/chroma/tree/master/styles)
 	// for available style names.
 	//
-	//
 	CodeStyle *string `json:"codeStyle,omitempty" yaml:"codeStyle,omitempty" mapstructure:"codeStyle,omitempty"`
 
 	// Emphasis corresponds to the JSON schema field "emphasis".
@@ -82,6 +81,14 @@ type Config struct {
 	//
 	CurrentNamespace string `json:"currentNamespace,omitempty" yaml:"currentNamespace,omitempty" mapstructure:"currentNamespace,omitempty"`
 
+	// The name of the workspace environment profile to use when running executables.
+	// If the current workspace does not define a profile with this name, no profile
+	// is applied.
+	// This can be overridden with the `--profile` flag or the `FLOW_PROFILE`
+	// environment variable.
+	//
+	CurrentProfile string `json:"currentProfile,omitempty" yaml:"currentProfile,omitempty" mapstructure:"currentProfile,omitempty"`
+
 	// The name of the currently active vault.
 	CurrentVault *string `json:"currentVault,omitempty" yaml:"currentVault,omitempty" mapstructure:"currentVault,omitempty"`
 
@@ -104,6 +111,13 @@ type Config struct {
 	//
 	DefaultTimeout time.Duration `json:"defaultTimeout,omitempty" yaml:"defaultTimeout,omitempty" mapstructure:"defaultTimeout,omitempty"`
 
+	// External importers for flow file imports that flow doesn't support natively.
+	// Executables named `flow-import-<name>` on the `PATH` are also used as
+	// importers; they're run with `--patterns`
+	// to list the filename patterns that they handle.
+	//
+	Importers []Importer `json:"importers,omitempty" yaml:"importers,omitempty" mapstructure:"importers,omitempty"`
+
 	// Interactive corresponds to the JSON schema field "interactive".
 	Interactive *Interactive `json:"interactive,omitempty" yaml:"interactive,omitempty" mapstructure:"interactive,omitempty"`
 
@@ -171,6 +185,28 @@ const ConfigWorkspaceModeFixed ConfigWorkspaceMode = "fixed"
 // to the workspace directory.
 type ConfigWorkspaces map[string]string
 
+// An external importer that generates executables from the imported files matching
+// its patterns.
+// The command is run with the file's path as its last argument and must print a
+// JSON list of executables.
+type Importer struct {
+	// Arguments to pass to the command before the file's path.
+	Args []string `json:"args,omitempty" yaml:"args,omitempty" mapstructure:"args,omitempty"`
+
+	// The name or path of the importer's executable.
+	Command string `json:"command" yaml:"command" mapstructure:"command"`
+
+	// The name of the importer. Executables generated by the importer are tagged with
+	// it.
+	Name string `json:"name,omitempty" yaml:"name,omitempty" mapstructure:"name,omitempty"`
+
+	// Filename glob patterns (e.g. `Cargo.toml`, `*.nix`) of the imported files that
+	// the importer handles.
+	// Declared importers take precedence over the built-in importers.
+	//
+	Patterns []string `json:"patterns" yaml:"patterns" mapstructure:"patterns"`
+}
+
 // Configurations for the interactive UI.
 type Interactive struct {
 	// Enabled corresponds to the JSON schema field "enabled".
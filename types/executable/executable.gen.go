@@ -158,6 +158,13 @@ type ExecExecutableType struct {
 
 	// Params corresponds to the JSON schema field "params".
 	Params ParameterList `json:"params,omitempty" yaml:"params,omitempty" mapstructure:"params,omitempty"`
+
+	// The prerequisites that the command runs before itself, in the order that they
+	// run (e.g. the prerequisite
+	// targets of an imported Makefile target). They're shown alongside the command
+	// but aren't run by flow.
+	//
+	Prerequisites []string `json:"prerequisites,omitempty" yaml:"prerequisites,omitempty" mapstructure:"prerequisites,omitempty"`
 }
 
 // The executable schema defines the structure of an executable in the Flow CLI.
@@ -178,13 +185,36 @@ type Executable struct {
 	// Exec corresponds to the JSON schema field "exec".
 	Exec *ExecExecutableType `json:"exec,omitempty" yaml:"exec,omitempty" mapstructure:"exec,omitempty"`
 
+	// A reference to another executable to extend. The executable inherits the
+	// referenced executable's type
+	// configuration, params, args, description, tags, annotations, and timeout, and
+	// only needs to set the fields
+	// it changes.
+	//
+	// Fields set on the executable are deep-merged over the referenced executable:
+	// scalar values replace the
+	// inherited ones, maps (such as headers and annotations) are merged, params and
+	// args are merged by `envKey`, and
+	// other lists are replaced. The name, aliases, verb aliases, and visibility are
+	// never inherited.
+	// If the workspace or namespace is omitted from the reference, the executable's
+	// own is used.
+	//
+	Extends Ref `json:"extends,omitempty" yaml:"extends,omitempty" mapstructure:"extends,omitempty"`
+
 	// flowFilePath corresponds to the JSON schema field "flowFilePath".
 	flowFilePath string `json:"flowFilePath,omitempty" yaml:"flowFilePath,omitempty" mapstructure:"flowFilePath,omitempty"`
 
+	// Graphql corresponds to the JSON schema field "graphql".
+	Graphql *GraphQLExecutableType `json:"graphql,omitempty" yaml:"graphql,omitempty" mapstructure:"graphql,omitempty"`
+
 	// inheritedDescription corresponds to the JSON schema field
 	// "inheritedDescription".
 	inheritedDescription string `json:"inheritedDescription,omitempty" yaml:"inheritedDescription,omitempty" mapstructure:"inheritedDescription,omitempty"`
 
+	// inheritedFields corresponds to the JSON schema field "inheritedFields".
+	inheritedFields []string `json:"inheritedFields,omitempty" yaml:"inheritedFields,omitempty" mapstructure:"inheritedFields,omitempty"`
+
 	// Launch corresponds to the JSON schema field "launch".
 	Launch *LaunchExecutableType `json:"launch,omitempty" yaml:"launch,omitempty" mapstructure:"launch,omitempty"`
 
@@ -245,6 +275,120 @@ type ExecutableTags common.Tags
 
 type ExecutableVisibility common.Visibility
 
+// Sends a GraphQL query or mutation over HTTP. The executable fails when the
+// response contains `errors`.
+// Auth, headers, TLS and response handling work the same as for `request`
+// executables.
+type GraphQLExecutableType struct {
+	// Args corresponds to the JSON schema field "args".
+	Args ArgumentList `json:"args,omitempty" yaml:"args,omitempty" mapstructure:"args,omitempty"`
+
+	// A list of [Expr](https://expr-lang.org/docs/language-definition) expressions
+	// that must evaluate to true
+	// for the executable to succeed, with the same variables as `transformResponse`.
+	//
+	Assert []string `json:"assert,omitempty" yaml:"assert,omitempty" mapstructure:"assert,omitempty"`
+
+	// Auth corresponds to the JSON schema field "auth".
+	Auth *RequestAuth `json:"auth,omitempty" yaml:"auth,omitempty" mapstructure:"auth,omitempty"`
+
+	// A map of keys to [Expr](https://expr-lang.org/docs/language-definition)
+	// expressions evaluated over the
+	// response, with the same variables as `transformResponse`. The results are saved
+	// to the data store of the
+	// current process.
+	//
+	Capture GraphQLExecutableTypeCapture `json:"capture,omitempty" yaml:"capture,omitempty" mapstructure:"capture,omitempty"`
+
+	// The URL of the GraphQL endpoint. Environment variables are expanded.
+	Endpoint string `json:"endpoint" yaml:"endpoint" mapstructure:"endpoint"`
+
+	// A map of headers to include in the request.
+	Headers GraphQLExecutableTypeHeaders `json:"headers,omitempty" yaml:"headers,omitempty" mapstructure:"headers,omitempty"`
+
+	// If set to true, the response `data` will be logged as program output.
+	LogResponse bool `json:"logResponse,omitempty" yaml:"logResponse,omitempty" mapstructure:"logResponse,omitempty"`
+
+	// The name of the operation to run when the document has more than one.
+	OperationName string `json:"operationName,omitempty" yaml:"operationName,omitempty" mapstructure:"operationName,omitempty"`
+
+	// Params corresponds to the JSON schema field "params".
+	Params ParameterList `json:"params,omitempty" yaml:"params,omitempty" mapstructure:"params,omitempty"`
+
+	// The URL of the proxy used for the request. Defaults to the workspace's
+	// `requestDefaults`, then to the
+	// `HTTP_PROXY`, `HTTPS_PROXY` and `NO_PROXY` environment variables.
+	//
+	Proxy string `json:"proxy,omitempty" yaml:"proxy,omitempty" mapstructure:"proxy,omitempty"`
+
+	// The GraphQL query or mutation document. Only one of `query` or `queryFile` can
+	// be set.
+	//
+	Query string `json:"query,omitempty" yaml:"query,omitempty" mapstructure:"query,omitempty"`
+
+	// The path to a file with the GraphQL document, relative to the flow file's
+	// directory.
+	// Only one of `query` or `queryFile` can be set.
+	//
+	QueryFile string `json:"queryFile,omitempty" yaml:"queryFile,omitempty" mapstructure:"queryFile,omitempty"`
+
+	// ResponseFile corresponds to the JSON schema field "responseFile".
+	ResponseFile *RequestResponseFile `json:"responseFile,omitempty" yaml:"responseFile,omitempty" mapstructure:"responseFile,omitempty"`
+
+	// The timeout for the request in Go duration format (e.g. 30s, 5m, 1h).
+	Timeout time.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty" mapstructure:"timeout,omitempty"`
+
+	// TLS settings for the request. Unset fields default to the workspace's
+	// `requestDefaults`.
+	Tls *GraphQLExecutableTypeTls `json:"tls,omitempty" yaml:"tls,omitempty" mapstructure:"tls,omitempty"`
+
+	// [Expr](https://expr-lang.org/docs/language-definition) expression used to
+	// transform the response before
+	// saving it to a file or outputting it.
+	//
+	// The following variables are available in the expression:
+	//   - `data`: The `data` of the response.
+	//   - `body`: The `data` of the response, as JSON.
+	//   - `status`: The response status string.
+	//   - `code`: The response status code.
+	//   - `headers`: The response headers.
+	//
+	// For example, `data.viewer.login`.
+	//
+	TransformResponse string `json:"transformResponse,omitempty" yaml:"transformResponse,omitempty" mapstructure:"transformResponse,omitempty"`
+
+	// A map of variables sent with the query. Environment variables in the values are
+	// expanded. Values of
+	// variables that the query declares with a type other than `String` or `ID` (e.g.
+	// `Int`, `Boolean`, lists
+	// and input objects) are sent as JSON when they're valid JSON; all other values
+	// are sent as strings.
+	//
+	Variables GraphQLExecutableTypeVariables `json:"variables,omitempty" yaml:"variables,omitempty" mapstructure:"variables,omitempty"`
+}
+
+// A map of keys to [Expr](https://expr-lang.org/docs/language-definition)
+// expressions evaluated over the
+// response, with the same variables as `transformResponse`. The results are saved
+// to the data store of the
+// current process.
+type GraphQLExecutableTypeCapture map[string]string
+
+// A map of headers to include in the request.
+type GraphQLExecutableTypeHeaders map[string]string
+
+// TLS settings for the request. Unset fields default to the workspace's
+// `requestDefaults`.
+type GraphQLExecutableTypeTls common.RequestTLS
+
+// A map of variables sent with the query. Environment variables in the values are
+// expanded. Values of
+// variables that the query declares with a type other than `String` or `ID` (e.g.
+// `Int`, `Boolean`, lists
+// and input objects) are sent as JSON when they're valid JSON; all other values
+// are sent as strings.
+type GraphQLExecutableTypeVariables map[string]string
+
 // Launches an application or opens a URI.
 type LaunchExecutableType struct {
 	// The application to launch the URI with.
@@ -394,32 +538,182 @@ type RenderExecutableType struct {
 	TemplateFile string `json:"templateFile" yaml:"templateFile" mapstructure:"templateFile"`
 }
 
+// API key authentication, sent in a header or a query parameter.
+type RequestAPIKeyAuth struct {
+	// Where the API key is sent.
+	In RequestAPIKeyAuthIn `json:"in,omitempty" yaml:"in,omitempty" mapstructure:"in,omitempty"`
+
+	// The name of the header or query parameter, e.g. `X-API-Key`.
+	Name string `json:"name" yaml:"name" mapstructure:"name"`
+
+	// A reference to the secret containing the API key.
+	SecretRef string `json:"secretRef,omitempty" yaml:"secretRef,omitempty" mapstructure:"secretRef,omitempty"`
+
+	// The API key. Environment variables (e.g. `$API_KEY`) are expanded.
+	Value string `json:"value,omitempty" yaml:"value,omitempty" mapstructure:"value,omitempty"`
+}
+
+type RequestAPIKeyAuthIn string
+
+const RequestAPIKeyAuthInHeader RequestAPIKeyAuthIn = "header"
+const RequestAPIKeyAuthInQuery RequestAPIKeyAuthIn = "query"
+
+// Authentication for a request. Only one of the authentication methods can be set.
+// Secrets are resolved from the vault when the request is made, and are never
+// logged.
+type RequestAuth struct {
+	// ApiKey corresponds to the JSON schema field "apiKey".
+	ApiKey *RequestAPIKeyAuth `json:"apiKey,omitempty" yaml:"apiKey,omitempty" mapstructure:"apiKey,omitempty"`
+
+	// Basic corresponds to the JSON schema field "basic".
+	Basic *RequestBasicAuth `json:"basic,omitempty" yaml:"basic,omitempty" mapstructure:"basic,omitempty"`
+
+	// Bearer corresponds to the JSON schema field "bearer".
+	Bearer *RequestBearerAuth `json:"bearer,omitempty" yaml:"bearer,omitempty" mapstructure:"bearer,omitempty"`
+
+	// Oauth2 corresponds to the JSON schema field "oauth2".
+	Oauth2 *RequestOAuth2Auth `json:"oauth2,omitempty" yaml:"oauth2,omitempty" mapstructure:"oauth2,omitempty"`
+}
+
+// HTTP basic authentication. The username and password can each be set as text or
+// read from the vault.
+type RequestBasicAuth struct {
+	// The password. Environment variables (e.g. `$PASSWORD`) are expanded.
+	Password string `json:"password,omitempty" yaml:"password,omitempty" mapstructure:"password,omitempty"`
+
+	// A reference to the secret containing the password.
+	PasswordSecretRef string `json:"passwordSecretRef,omitempty" yaml:"passwordSecretRef,omitempty" mapstructure:"passwordSecretRef,omitempty"`
+
+	// The username. Environment variables (e.g. `$USER`) are expanded.
+	Username string `json:"username,omitempty" yaml:"username,omitempty" mapstructure:"username,omitempty"`
+
+	// A reference to the secret containing the username.
+	UsernameSecretRef string `json:"usernameSecretRef,omitempty" yaml:"usernameSecretRef,omitempty" mapstructure:"usernameSecretRef,omitempty"`
+}
+
+// Bearer token authentication, sent in the `Authorization` header.
+type RequestBearerAuth struct {
+	// The token. Environment variables (e.g. `$TOKEN`) are expanded.
+	Token string `json:"token,omitempty" yaml:"token,omitempty" mapstructure:"token,omitempty"`
+
+	// A reference to the secret containing the token.
+	TokenSecretRef string `json:"tokenSecretRef,omitempty" yaml:"tokenSecretRef,omitempty" mapstructure:"tokenSecretRef,omitempty"`
+}
+
 // Makes an HTTP request.
 type RequestExecutableType struct {
 	// Args corresponds to the JSON schema field "args".
 	Args ArgumentList `json:"args,omitempty" yaml:"args,omitempty" mapstructure:"args,omitempty"`
 
-	// The body of the request.
+	// A list of [Expr](https://expr-lang.org/docs/language-definition) expressions
+	// that must evaluate to true
+	// for the executable to succeed. They are evaluated over the response, before
+	// it's transformed, with the
+	// same variables as `transformResponse`.
+	//
+	// For example, `fromJSON(body).ready == true` or `headers["Content-Type"][0] ==
+	// "application/json"`.
+	//
+	Assert []string `json:"assert,omitempty" yaml:"assert,omitempty" mapstructure:"assert,omitempty"`
+
+	// Auth corresponds to the JSON schema field "auth".
+	Auth *RequestAuth `json:"auth,omitempty" yaml:"auth,omitempty" mapstructure:"auth,omitempty"`
+
+	// The body of the request. The `Content-Type` header is set to `application/json`
+	// when the body is valid JSON.
+	// Only one of `body`, `rawBody`, `bodyFile`, `form` or `multipart` can be set.
+	//
 	Body string `json:"body,omitempty" yaml:"body,omitempty" mapstructure:"body,omitempty"`
 
+	// The path to a file to send as the body of the request, relative to the flow
+	// file's directory. The
+	// `Content-Type` header is set from the file's extension. It can be set along
+	// with `body`, which is sent
+	// instead when the path is empty after expanding environment variables.
+	//
+	BodyFile string `json:"bodyFile,omitempty" yaml:"bodyFile,omitempty" mapstructure:"bodyFile,omitempty"`
+
+	// A map of keys to [Expr](https://expr-lang.org/docs/language-definition)
+	// expressions evaluated over the
+	// response. The results are saved to the data store of the current process, where
+	// later steps can read
+	// them with `flow cache get` or `store["key"]` in conditions.
+	//
+	// For example, `{id: fromJSON(body).id}` saves the `id` field of a JSON response.
+	//
+	Capture RequestExecutableTypeCapture `json:"capture,omitempty" yaml:"capture,omitempty" mapstructure:"capture,omitempty"`
+
+	// If set to false, redirect responses are returned instead of followed.
+	// Defaults to the workspace's `requestDefaults`, where redirects are followed
+	// unless it's set.
+	//
+	FollowRedirects *bool `json:"followRedirects,omitempty" yaml:"followRedirects,omitempty" mapstructure:"followRedirects,omitempty"`
+
+	// A map of form fields to send as an `application/x-www-form-urlencoded` body.
+	// Environment variables in the
+	// values are expanded.
+	//
+	Form RequestExecutableTypeForm `json:"form,omitempty" yaml:"form,omitempty" mapstructure:"form,omitempty"`
+
 	// A map of headers to include in the request.
 	Headers RequestExecutableTypeHeaders `json:"headers,omitempty" yaml:"headers,omitempty" mapstructure:"headers,omitempty"`
 
 	// If set to true, the response will be logged as program output.
 	LogResponse bool `json:"logResponse,omitempty" yaml:"logResponse,omitempty" mapstructure:"logResponse,omitempty"`
 
+	// The maximum number of redirects that are followed. Defaults to the workspace's
+	// `requestDefaults`, then 10.
+	// Setting it to 0 returns redirect responses instead of following them, like
+	// `followRedirects: false`.
+	//
+	MaxRedirects *int `json:"maxRedirects,omitempty" yaml:"maxRedirects,omitempty" mapstructure:"maxRedirects,omitempty"`
+
 	// The HTTP method to use when making the request.
 	Method RequestExecutableTypeMethod `json:"method,omitempty" yaml:"method,omitempty" mapstructure:"method,omitempty"`
 
+	// Multipart corresponds to the JSON schema field "multipart".
+	Multipart *RequestMultipart `json:"multipart,omitempty" yaml:"multipart,omitempty" mapstructure:"multipart,omitempty"`
+
+	// Paginate corresponds to the JSON schema field "paginate".
+	Paginate *RequestPaginate `json:"paginate,omitempty" yaml:"paginate,omitempty" mapstructure:"paginate,omitempty"`
+
 	// Params corresponds to the JSON schema field "params".
 	Params ParameterList `json:"params,omitempty" yaml:"params,omitempty" mapstructure:"params,omitempty"`
 
+	// Poll corresponds to the JSON schema field "poll".
+	Poll *RequestPoll `json:"poll,omitempty" yaml:"poll,omitempty" mapstructure:"poll,omitempty"`
+
+	// The URL of the proxy used for the request. Defaults to the workspace's
+	// `requestDefaults`, then to the
+	// `HTTP_PROXY`, `HTTPS_PROXY` and `NO_PROXY` environment variables.
+	//
+	Proxy string `json:"proxy,omitempty" yaml:"proxy,omitempty" mapstructure:"proxy,omitempty"`
+
+	// A map of query parameters to add to the URL. Values are URL-encoded, and
+	// environment variables in them are
+	// expanded. Parameters whose value is only made up of environment variables that
+	// are unset or empty are omitted.
+	//
+	Query RequestExecutableTypeQuery `json:"query,omitempty" yaml:"query,omitempty" mapstructure:"query,omitempty"`
+
+	// A body that's sent as-is after expanding environment variables, e.g. XML or
+	// plain text. Unlike `body`, it's
+	// never evaluated as an expression. The `Content-Type` header is set to
+	// `application/json` when the body is
+	// valid JSON.
+	//
+	RawBody string `json:"rawBody,omitempty" yaml:"rawBody,omitempty" mapstructure:"rawBody,omitempty"`
+
 	// ResponseFile corresponds to the JSON schema field "responseFile".
 	ResponseFile *RequestResponseFile `json:"responseFile,omitempty" yaml:"responseFile,omitempty" mapstructure:"responseFile,omitempty"`
 
 	// The timeout for the request in Go duration format (e.g. 30s, 5m, 1h).
 	Timeout time.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty" mapstructure:"timeout,omitempty"`
 
+	// TLS settings for the request. Unset fields default to the workspace's
+	// `requestDefaults`.
+	Tls *RequestExecutableTypeTls `json:"tls,omitempty" yaml:"tls,omitempty" mapstructure:"tls,omitempty"`
+
 	// [Expr](https://expr-lang.org/docs/language-definition) expression used to
 	// transform the response before
 	// saving it to a file or outputting it.
@@ -445,6 +739,20 @@ type RequestExecutableType struct {
 	ValidStatusCodes []int `json:"validStatusCodes,omitempty" yaml:"validStatusCodes,omitempty" mapstructure:"validStatusCodes,omitempty"`
 }
 
+// A map of keys to [Expr](https://expr-lang.org/docs/language-definition)
+// expressions evaluated over the
+// response. The results are saved to the data store of the current process, where
+// later steps can read
+// them with `flow cache get` or `store["key"]` in conditions.
+//
+// For example, `{id: fromJSON(body).id}` saves the `id` field of a JSON response.
+type RequestExecutableTypeCapture map[string]string
+
+// A map of form fields to send as an `application/x-www-form-urlencoded` body.
+// Environment variables in the
+// values are expanded.
+type RequestExecutableTypeForm map[string]string
+
 // A map of headers to include in the request.
 type RequestExecutableTypeHeaders map[string]string
 
@@ -452,10 +760,147 @@ type RequestExecutableTypeMethod string
 
 const RequestExecutableTypeMethodDELETE RequestExecutableTypeMethod = "DELETE"
 const RequestExecutableTypeMethodGET RequestExecutableTypeMethod = "GET"
+const RequestExecutableTypeMethodHEAD RequestExecutableTypeMethod = "HEAD"
+const RequestExecutableTypeMethodOPTIONS RequestExecutableTypeMethod = "OPTIONS"
 const RequestExecutableTypeMethodPATCH RequestExecutableTypeMethod = "PATCH"
 const RequestExecutableTypeMethodPOST RequestExecutableTypeMethod = "POST"
 const RequestExecutableTypeMethodPUT RequestExecutableTypeMethod = "PUT"
 
+// A map of query parameters to add to the URL. Values are URL-encoded, and
+// environment variables in them are
+// expanded. Parameters whose value is only made up of environment variables that
+// are unset or empty are omitted.
+type RequestExecutableTypeQuery map[string]string
+
+// TLS settings for the request. Unset fields default to the workspace's
+// `requestDefaults`.
+type RequestExecutableTypeTls common.RequestTLS
+
+// A `multipart/form-data` body, e.g. for uploading files.
+type RequestMultipart struct {
+	// A map of form fields to include. Environment variables in the values are
+	// expanded.
+	Fields RequestMultipartFields `json:"fields,omitempty" yaml:"fields,omitempty" mapstructure:"fields,omitempty"`
+
+	// A map of form field names to the paths of the files to upload. Paths are
+	// relative to the flow file's
+	// directory, unless they start with `//` (the workspace root).
+	//
+	Files RequestMultipartFiles `json:"files,omitempty" yaml:"files,omitempty" mapstructure:"files,omitempty"`
+}
+
+// A map of form fields to include. Environment variables in the values are
+// expanded.
+type RequestMultipartFields map[string]string
+
+// A map of form field names to the paths of the files to upload. Paths are
+// relative to the flow file's
+// directory, unless they start with `//` (the workspace root).
+type RequestMultipartFiles map[string]string
+
+// OAuth2 client credentials authentication. An access token is requested from the
+// token URL and sent as a bearer
+// token. Tokens are cached until they expire, so that they're reused across
+// executions.
+type RequestOAuth2Auth struct {
+	// The client ID. Environment variables (e.g. `$CLIENT_ID`) are expanded.
+	ClientId string `json:"clientId,omitempty" yaml:"clientId,omitempty" mapstructure:"clientId,omitempty"`
+
+	// A reference to the secret containing the client ID.
+	ClientIdSecretRef string `json:"clientIdSecretRef,omitempty" yaml:"clientIdSecretRef,omitempty" mapstructure:"clientIdSecretRef,omitempty"`
+
+	// The client secret. Environment variables (e.g. `$CLIENT_SECRET`) are expanded.
+	ClientSecret string `json:"clientSecret,omitempty" yaml:"clientSecret,omitempty" mapstructure:"clientSecret,omitempty"`
+
+	// A reference to the secret containing the client secret.
+	ClientSecretRef string `json:"clientSecretRef,omitempty" yaml:"clientSecretRef,omitempty" mapstructure:"clientSecretRef,omitempty"`
+
+	// If set to true, the client credentials are sent in the token request's body
+	// instead of its `Authorization`
+	// header.
+	//
+	CredentialsInBody bool `json:"credentialsInBody,omitempty" yaml:"credentialsInBody,omitempty" mapstructure:"credentialsInBody,omitempty"`
+
+	// The scopes to request.
+	Scopes []string `json:"scopes,omitempty" yaml:"scopes,omitempty" mapstructure:"scopes,omitempty"`
+
+	// The URL of the authorization server's token endpoint.
+	TokenURL string `json:"tokenURL" yaml:"tokenURL" mapstructure:"tokenURL"`
+}
+
+// Configuration for collecting the items of a paginated API across pages. The
+// items of every page are combined
+// into a JSON array, which replaces the response body for `transformResponse` and
+// `responseFile`.
+type RequestPaginate struct {
+	// [Expr](https://expr-lang.org/docs/language-definition) expression that returns
+	// the cursor of the next page.
+	// Pagination stops when it's empty. Required for the `cursor` strategy.
+	//
+	// For example, `fromJSON(body).next_cursor`.
+	//
+	Cursor string `json:"cursor,omitempty" yaml:"cursor,omitempty" mapstructure:"cursor,omitempty"`
+
+	// The query parameter the cursor is sent in.
+	CursorParam string `json:"cursorParam,omitempty" yaml:"cursorParam,omitempty" mapstructure:"cursorParam,omitempty"`
+
+	// [Expr](https://expr-lang.org/docs/language-definition) expression that selects
+	// the list of items of a page,
+	// with the same variables as `transformResponse`. Defaults to `fromJSON(body)`.
+	//
+	// For example, `fromJSON(body).data`.
+	//
+	Items string `json:"items,omitempty" yaml:"items,omitempty" mapstructure:"items,omitempty"`
+
+	// The maximum number of pages that are requested. If more pages are available, a
+	// warning is logged.
+	//
+	MaxPages int `json:"maxPages,omitempty" yaml:"maxPages,omitempty" mapstructure:"maxPages,omitempty"`
+
+	// The query parameter the page number is sent in.
+	PageParam string `json:"pageParam,omitempty" yaml:"pageParam,omitempty" mapstructure:"pageParam,omitempty"`
+
+	// The number of the first page. Defaults to 1.
+	StartPage *int `json:"startPage,omitempty" yaml:"startPage,omitempty" mapstructure:"startPage,omitempty"`
+
+	// How the next page is requested.
+	// - `link`: Follow the `rel="next"` URL of the `Link` response header.
+	// - `cursor`: Set the `cursorParam` query parameter to the result of the `cursor`
+	// expression.
+	// - `page`: Increment the `pageParam` query parameter until a page has no items.
+	//
+	Strategy RequestPaginateStrategy `json:"strategy" yaml:"strategy" mapstructure:"strategy"`
+}
+
+type RequestPaginateStrategy string
+
+const RequestPaginateStrategyCursor RequestPaginateStrategy = "cursor"
+const RequestPaginateStrategyLink RequestPaginateStrategy = "link"
+const RequestPaginateStrategyPage RequestPaginateStrategy = "page"
+
+// Configuration for sending a request repeatedly until its response matches a
+// condition.
+// Responses with an unexpected status code and failed requests are retried until
+// the timeout.
+type RequestPoll struct {
+	// The time to wait between requests in Go duration format (e.g. 5s, 1m).
+	Interval time.Duration `json:"interval,omitempty" yaml:"interval,omitempty" mapstructure:"interval,omitempty"`
+
+	// The maximum time to poll for in Go duration format (e.g. 30s, 10m). When it's
+	// reached, the executable
+	// fails with the last response.
+	//
+	Timeout time.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty" mapstructure:"timeout,omitempty"`
+
+	// [Expr](https://expr-lang.org/docs/language-definition) expression evaluated
+	// over each response. Polling
+	// stops once it's true. The same variables as `transformResponse` are available.
+	//
+	// For example, `fromJSON(body).state == "done"`.
+	//
+	Until string `json:"until" yaml:"until" mapstructure:"until"`
+}
+
 // Configuration for saving the response of a request to a file.
 type RequestResponseFile struct {
 	// Dir corresponds to the JSON schema field "dir".
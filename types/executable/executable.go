@@ -95,6 +95,115 @@ func (e *Executable) SetInheritedFields(flowFile *FlowFile) {
 		}
 	}
 	e.inheritedDescription = strings.Join([]string{flowFile.Description, descFromFIle}, "\n")
+	e.inheritFlowFileDefaults(flowFile)
+}
+
+// inheritFlowFileDefaults applies the flow file's shared params, args, dir, timeout, log mode, and container
+// to the executable. Values set on the executable always take precedence: params and args are merged by env key,
+// and the remaining fields are only used when the executable leaves them unset.
+func (e *Executable) inheritFlowFileDefaults(flowFile *FlowFile) {
+	e.inheritedFields = nil
+	if e.Timeout == nil && flowFile.Timeout != nil {
+		t := *flowFile.Timeout
+		e.Timeout = &t
+		e.inheritedFields = append(e.inheritedFields, "timeout")
+	}
+
+	params, args, dir := e.typeEnvFields()
+	if params != nil && len(flowFile.Params) > 0 {
+		var keys []string
+		*params, keys = mergeByEnvKey(flowFile.Params, *params, paramKey)
+		for _, k := range keys {
+			e.inheritedFields = append(e.inheritedFields, "params."+k)
+		}
+	}
+	if args != nil && len(flowFile.Args) > 0 {
+		var keys []string
+		*args, keys = mergeByEnvKey(flowFile.Args, *args, argKey)
+		for _, k := range keys {
+			e.inheritedFields = append(e.inheritedFields, "args."+k)
+		}
+	}
+	if dir != nil && *dir == "" && flowFile.Dir != "" {
+		*dir = flowFile.Dir
+		e.inheritedFields = append(e.inheritedFields, "dir")
+	}
+
+	if e.Exec == nil {
+		return
+	}
+	if e.Exec.LogMode == "" && flowFile.LogMode != "" {
+		e.Exec.LogMode = flowFile.LogMode
+		e.inheritedFields = append(e.inheritedFields, "logMode")
+	}
+	if e.Exec.Container == nil && flowFile.Container != nil {
+		c := ExecContainer(*flowFile.Container)
+		c.Volumes = slices.Clone(c.Volumes)
+		e.Exec.Container = &c
+		e.inheritedFields = append(e.inheritedFields, "container")
+	}
+}
+
+// InheritedFields returns the fields that the executable inherited from its flow file. Inherited params and
+// args are listed by their env key (e.g. `params.API_TOKEN`).
+func (e *Executable) InheritedFields() []string {
+	return e.inheritedFields
+}
+
+// typeEnvFields returns pointers to the params, args, and dir fields of the executable's type. The dir pointer is
+// nil for types that do not run from a directory.
+func (e *Executable) typeEnvFields() (*ParameterList, *ArgumentList, *Directory) {
+	switch {
+	case e.Exec != nil:
+		return &e.Exec.Params, &e.Exec.Args, &e.Exec.Dir
+	case e.Launch != nil:
+		return &e.Launch.Params, &e.Launch.Args, nil
+	case e.Request != nil:
+		return &e.Request.Params, &e.Request.Args, nil
+	case e.Graphql != nil:
+		return &e.Graphql.Params, &e.Graphql.Args, nil
+	case e.Render != nil:
+		return &e.Render.Params, &e.Render.Args, &e.Render.Dir
+	case e.Serial != nil:
+		return &e.Serial.Params, &e.Serial.Args, &e.Serial.Dir
+	case e.Parallel != nil:
+		return &e.Parallel.Params, &e.Parallel.Args, &e.Parallel.Dir
+	default:
+		return nil, nil, nil
+	}
+}
+
+func paramKey(p Parameter) string {
+	if p.EnvKey == "" {
+		return p.EnvFile
+	}
+	return p.EnvKey
+}
+
+func argKey(a Argument) string {
+	if a.EnvKey == "" {
+		return a.OutputFile
+	}
+	return a.EnvKey
+}
+
+// mergeByEnvKey returns the inherited items followed by the executable's own items, dropping any inherited item
+// whose key is also defined by the executable. Items without a key are always kept. The keys of the inherited items
+// that were kept are also returned.
+func mergeByEnvKey[T any](inherited, own []T, key func(T) string) ([]T, []string) {
+	merged := make([]T, 0, len(inherited)+len(own))
+	var keys []string
+	for _, item := range inherited {
+		k := key(item)
+		if k != "" && slices.ContainsFunc(own, func(o T) bool { return key(o) == k }) {
+			continue
+		}
+		merged = append(merged, item)
+		if k != "" {
+			keys = append(keys, k)
+		}
+	}
+	return append(merged, own...), keys
 }
 
 func (e *Executable) enriched() *enrichedExecutable {
@@ -146,6 +255,7 @@ func (e *Executable) Env() *ExecutableEnvironment {
 		e.Exec,
 		e.Launch,
 		e.Request,
+		e.Graphql,
 		e.Render,
 		e.Serial,
 		e.Parallel,
@@ -266,6 +376,7 @@ func (e *Executable) Validate() error {
 		e.Exec,
 		e.Launch,
 		e.Request,
+		e.Graphql,
 		e.Render,
 		e.Serial,
 		e.Parallel,
@@ -280,6 +391,18 @@ func (e *Executable) Validate() error {
 		}
 	}
 
+	if e.Request != nil {
+		if err := e.Request.Validate(); err != nil {
+			return fmt.Errorf("request validation failed - %w", err)
+		}
+	}
+
+	if e.Graphql != nil {
+		if err := e.Graphql.Validate(); err != nil {
+			return fmt.Errorf("graphql validation failed - %w", err)
+		}
+	}
+
 	if e.Workspace() == "" {
 		return fmt.Errorf("workspace was not set")
 	}
@@ -521,6 +644,13 @@ func MustParseExecutableID(id string) (workspace, namespace, name string) {
 	if id == "" {
 		return WildcardWorkspace, "", ""
 	}
+	if ws, rest, ok := splitRemoteID(id); ok {
+		if rest == "" {
+			return ws, "", ""
+		}
+		_, ns, name := MustParseExecutableID(rest)
+		return ws, ns, name
+	}
 
 	parts := strings.Split(id, "/")
 	switch len(parts) {
@@ -3,7 +3,9 @@ package executable
 
 import (
 	"fmt"
+	"maps"
 	"path/filepath"
+	"slices"
 	"strings"
 )
 
@@ -17,6 +19,13 @@ func execMarkdown(e *Executable) string {
 	if e.Timeout != nil {
 		mkdwn += fmt.Sprintf("**Timeout:** %s\n", e.Timeout.String())
 	}
+	if len(e.inheritedFields) > 0 {
+		fields := make([]string, 0, len(e.inheritedFields))
+		for _, f := range e.inheritedFields {
+			fields = append(fields, fmt.Sprintf("`%s`", f))
+		}
+		mkdwn += fmt.Sprintf("**Inherited from flow file:** %s\n", strings.Join(fields, ", "))
+	}
 	if len(e.Aliases) > 0 {
 		mkdwn += "**Aliases**\n"
 		for _, alias := range e.Aliases {
@@ -74,6 +83,8 @@ func execTypeMarkdown(spec *Executable) string {
 		mkdwn += launchExecMarkdown(spec.Env(), spec.Launch)
 	case spec.Request != nil:
 		mkdwn += requestExecMarkdown(spec.Env(), spec.Request)
+	case spec.Graphql != nil:
+		mkdwn += graphqlExecMarkdown(spec.Env(), spec.Graphql)
 	case spec.Render != nil:
 		mkdwn += renderExecMarkdown(spec.Env(), spec.Render)
 	case spec.Serial != nil:
@@ -139,9 +150,24 @@ func requestExecMarkdown(e *ExecutableEnvironment, r *RequestExecutableType) str
 	if r.LogResponse {
 		mkdwn += "**Log Response:** enabled\n"
 	}
+	if method := r.Auth.method(); method != "" {
+		mkdwn += fmt.Sprintf("**Auth:** %s\n", method)
+	}
 	if r.Body != "" {
 		mkdwn += fmt.Sprintf("**Body:**\n```\n%s\n```\n", r.Body)
 	}
+	if r.RawBody != "" {
+		mkdwn += fmt.Sprintf("**Body:**\n```\n%s\n```\n", r.RawBody)
+	}
+	if r.BodyFile != "" {
+		mkdwn += fmt.Sprintf("**Body File:** `%s`\n", r.BodyFile)
+	}
+	if r.Proxy != "" {
+		mkdwn += fmt.Sprintf("**Proxy:** %s\n", r.Proxy)
+	}
+	if r.FollowRedirects != nil && !*r.FollowRedirects {
+		mkdwn += "**Follow Redirects:** false\n"
+	}
 
 	if len(r.Headers) > 0 {
 		mkdwn += "\n**Headers**\n"
@@ -162,6 +188,24 @@ func requestExecMarkdown(e *ExecutableEnvironment, r *RequestExecutableType) str
 			mkdwn += fmt.Sprintf("**Response Saved As:** %s\n", r.ResponseFile.SaveAs)
 		}
 	}
+	if r.Poll != nil {
+		mkdwn += fmt.Sprintf("**Poll Until:** `%s`\n", r.Poll.Until)
+	}
+	if r.Paginate != nil {
+		mkdwn += fmt.Sprintf("**Paginate:** %s\n", r.Paginate.Strategy)
+	}
+	if len(r.Assert) > 0 {
+		mkdwn += "**Assertions**\n"
+		for _, assertion := range r.Assert {
+			mkdwn += fmt.Sprintf("- `%s`\n", assertion)
+		}
+	}
+	if len(r.Capture) > 0 {
+		mkdwn += "**Captured Values**\n"
+		for _, key := range slices.Sorted(maps.Keys(r.Capture)) {
+			mkdwn += fmt.Sprintf("- %s: `%s`\n", key, r.Capture[key])
+		}
+	}
 	if r.TransformResponse != "" {
 		mkdwn += fmt.Sprintf("**Transformation Expression:**\n ```\n%s\n```\n", r.TransformResponse)
 	}
@@ -170,6 +214,47 @@ func requestExecMarkdown(e *ExecutableEnvironment, r *RequestExecutableType) str
 	return mkdwn
 }
 
+func graphqlExecMarkdown(e *ExecutableEnvironment, g *GraphQLExecutableType) string {
+	if g == nil {
+		return ""
+	}
+	mkdwn := "## GraphQL Configuration\n"
+	mkdwn += fmt.Sprintf("**Endpoint:** [%s](%s)\n", g.Endpoint, g.Endpoint)
+	if g.Timeout != 0 {
+		mkdwn += fmt.Sprintf("**Request Timeout:** %s\n", g.Timeout)
+	}
+	if g.LogResponse {
+		mkdwn += "**Log Response:** enabled\n"
+	}
+	if method := g.Auth.method(); method != "" {
+		mkdwn += fmt.Sprintf("**Auth:** %s\n", method)
+	}
+	if g.OperationName != "" {
+		mkdwn += fmt.Sprintf("**Operation:** %s\n", g.OperationName)
+	}
+	if g.Query != "" {
+		mkdwn += fmt.Sprintf("**Query:**\n```graphql\n%s\n```\n", strings.TrimSpace(g.Query))
+	}
+	if g.QueryFile != "" {
+		mkdwn += fmt.Sprintf("**Query File:** `%s`\n", g.QueryFile)
+	}
+	if len(g.Variables) > 0 {
+		mkdwn += "**Variables**\n"
+		for _, key := range slices.Sorted(maps.Keys(g.Variables)) {
+			mkdwn += fmt.Sprintf("- %s: %s\n", key, g.Variables[key])
+		}
+	}
+	if g.ResponseFile != nil {
+		mkdwn += fmt.Sprintf("**Response Saved To:** %s\n", g.ResponseFile.Filename)
+	}
+	if g.TransformResponse != "" {
+		mkdwn += fmt.Sprintf("**Transformation Expression:**\n ```\n%s\n```\n", g.TransformResponse)
+	}
+
+	mkdwn += execEnvTable(e)
+	return mkdwn
+}
+
 func renderExecMarkdown(e *ExecutableEnvironment, r *RenderExecutableType) string {
 	if r == nil {
 		return ""
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"slices"
 	"testing"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -118,6 +119,217 @@ var _ = Describe("Executable", func() {
 		})
 	})
 
+	Describe("SetInheritedFields", func() {
+		var flowFile *executable.FlowFile
+
+		BeforeEach(func() {
+			timeout := 5 * time.Minute
+			flowFile = &executable.FlowFile{
+				Params: executable.ParameterList{
+					{EnvKey: "TOKEN", SecretRef: "token"},
+					{EnvKey: "REGION", Text: "us-east-1"},
+				},
+				Args:    executable.ArgumentList{{EnvKey: "VERBOSE", Flag: "verbose", Type: "bool"}},
+				Dir:     "//src",
+				Timeout: &timeout,
+				LogMode: "text",
+				Container: &executable.FlowFileContainer{
+					Image: "alpine:3",
+				},
+			}
+		})
+
+		It("should apply the flow file defaults to unset fields", func() {
+			exec.Timeout = nil
+			exec.SetInheritedFields(flowFile)
+			Expect(exec.Timeout).To(HaveValue(Equal(5 * time.Minute)))
+			Expect(exec.Exec.Dir).To(Equal(executable.Directory("//src")))
+			Expect(string(exec.Exec.LogMode)).To(Equal("text"))
+			Expect(exec.Exec.Container).ToNot(BeNil())
+			Expect(exec.Exec.Container.Image).To(Equal("alpine:3"))
+			Expect(exec.Env().Params).To(HaveLen(2))
+			Expect(exec.Env().Args).To(HaveLen(1))
+			Expect(exec.InheritedFields()).To(ConsistOf(
+				"timeout", "params.TOKEN", "params.REGION", "args.VERBOSE", "dir", "logMode", "container",
+			))
+		})
+
+		It("should not override values set on the executable", func() {
+			timeout := time.Minute
+			exec.Timeout = &timeout
+			exec.Exec.Dir = "//other"
+			exec.Exec.Params = executable.ParameterList{{EnvKey: "REGION", Text: "eu-west-1"}}
+			exec.SetInheritedFields(flowFile)
+			Expect(exec.Timeout).To(HaveValue(Equal(time.Minute)))
+			Expect(exec.Exec.Dir).To(Equal(executable.Directory("//other")))
+			Expect(exec.Exec.Params).To(Equal(executable.ParameterList{
+				{EnvKey: "TOKEN", SecretRef: "token"},
+				{EnvKey: "REGION", Text: "eu-west-1"},
+			}))
+			Expect(exec.InheritedFields()).To(ContainElement("params.TOKEN"))
+			Expect(exec.InheritedFields()).ToNot(ContainElements("params.REGION", "dir", "timeout"))
+		})
+
+		It("should keep params and args without a key", func() {
+			one, two := 1, 2
+			flowFile.Params = executable.ParameterList{{Text: "a"}, {EnvKey: "TOKEN", SecretRef: "token"}}
+			flowFile.Args = executable.ArgumentList{{Pos: &one}}
+			exec.Exec.Params = executable.ParameterList{{Text: "b"}}
+			exec.Exec.Args = executable.ArgumentList{{Pos: &two}}
+			exec.SetInheritedFields(flowFile)
+			Expect(exec.Exec.Params).To(Equal(executable.ParameterList{
+				{Text: "a"}, {EnvKey: "TOKEN", SecretRef: "token"}, {Text: "b"},
+			}))
+			Expect(exec.Exec.Args).To(Equal(executable.ArgumentList{{Pos: &one}, {Pos: &two}}))
+			Expect(exec.InheritedFields()).To(ContainElement("params.TOKEN"))
+			Expect(exec.InheritedFields()).ToNot(ContainElements("params.", "args."))
+		})
+
+		It("should only apply exec defaults to exec executables", func() {
+			exec.Exec = nil
+			exec.Serial = &executable.SerialExecutableType{
+				Execs: executable.SerialRefConfigList{{Cmd: "echo hello"}},
+			}
+			exec.SetInheritedFields(flowFile)
+			Expect(exec.Serial.Dir).To(Equal(executable.Directory("//src")))
+			Expect(exec.InheritedFields()).ToNot(ContainElements("logMode", "container"))
+		})
+
+		It("should show the inherited fields in the markdown", func() {
+			exec.SetInheritedFields(flowFile)
+			Expect(exec.Markdown()).To(ContainSubstring("**Inherited from flow file:**"))
+			Expect(exec.Markdown()).To(ContainSubstring("`params.TOKEN`"))
+		})
+	})
+
+	Describe("Extend", func() {
+		var base *executable.Executable
+
+		BeforeEach(func() {
+			base = &executable.Executable{
+				Verb:        "deploy",
+				Name:        "base",
+				Aliases:     []string{"b"},
+				Description: "base deployment",
+				Tags:        []string{"k8s"},
+				Exec: &executable.ExecExecutableType{
+					Cmd: "kubectl apply",
+					Params: executable.ParameterList{
+						{EnvKey: "CONTEXT", Text: "dev"},
+						{EnvKey: "TOKEN", SecretRef: "token"},
+					},
+				},
+			}
+			base.SetContext(testWsName, testWorkspacePath, "", "base.flow")
+		})
+
+		It("should merge the extending executable's fields over the base", func() {
+			e := &executable.Executable{
+				Verb:    "deploy",
+				Name:    "prod",
+				Extends: "deploy base",
+				Tags:    []string{"prod"},
+				Exec: &executable.ExecExecutableType{
+					Params: executable.ParameterList{{EnvKey: "CONTEXT", Text: "prod"}},
+				},
+			}
+			e.SetContext(testWsName, testWorkspacePath, "ns", "prod.flow")
+			merged, err := e.Extend(base, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(merged.Ref().String()).To(Equal("deploy workspace/ns:prod"))
+			Expect(merged.Aliases).To(BeEmpty())
+			Expect(merged.Description).To(Equal("base deployment"))
+			Expect(merged.Tags).To(ConsistOf("k8s", "prod"))
+			Expect(merged.Exec.Cmd).To(Equal("kubectl apply"))
+			Expect(merged.Exec.Params).To(ConsistOf(
+				executable.Parameter{EnvKey: "CONTEXT", Text: "prod"},
+				executable.Parameter{EnvKey: "TOKEN", SecretRef: "token"},
+			))
+			Expect(base.Exec.Params[0].Text).To(Equal("dev"))
+		})
+
+		It("should combine tags without duplicates", func() {
+			base.Tags = []string{"k8s", "deploy"}
+			e := &executable.Executable{
+				Verb: "deploy", Name: "prod", Extends: "deploy base", Tags: []string{"prod", "k8s"},
+			}
+			e.SetContext(testWsName, testWorkspacePath, "", "prod.flow")
+			merged, err := e.Extend(base, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(merged.Tags).To(Equal(executable.ExecutableTags{"k8s", "deploy", "prod"}))
+		})
+
+		It("should keep the flow file's visibility", func() {
+			public := executable.FlowFileVisibility(common.VisibilityPublic)
+			flowFile := &executable.FlowFile{
+				Visibility: &public,
+				Executables: executable.ExecutableList{
+					{Verb: "deploy", Name: "prod", Extends: "deploy base"},
+				},
+			}
+			flowFile.SetContext(testWsName, testWorkspacePath, "prod.flow")
+			merged, err := flowFile.Executables[0].Extend(base, flowFile)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(merged.Visibility).To(HaveValue(Equal(executable.ExecutableVisibility(common.VisibilityPublic))))
+		})
+
+		It("should fail when the executable types differ", func() {
+			e := &executable.Executable{
+				Verb: "deploy", Name: "prod", Extends: "deploy base",
+				Serial: &executable.SerialExecutableType{},
+			}
+			e.SetContext(testWsName, testWorkspacePath, "", "prod.flow")
+			_, err := e.Extend(base, nil)
+			Expect(err).To(MatchError(ContainSubstring("cannot extend a Exec executable")))
+		})
+
+		It("should merge graphql configuration over a graphql base", func() {
+			base.Exec = nil
+			base.Graphql = &executable.GraphQLExecutableType{
+				Endpoint: "https://dev.example.com/graphql",
+				Query:    "{ viewer { login } }",
+			}
+			e := &executable.Executable{
+				Verb: "deploy", Name: "prod", Extends: "deploy base",
+				Graphql: &executable.GraphQLExecutableType{Endpoint: "https://prod.example.com/graphql"},
+			}
+			e.SetContext(testWsName, testWorkspacePath, "", "prod.flow")
+			merged, err := e.Extend(base, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(merged.Graphql.Endpoint).To(Equal("https://prod.example.com/graphql"))
+			Expect(merged.Graphql.Query).To(Equal("{ viewer { login } }"))
+		})
+
+		It("should fail when extending a graphql base with another type", func() {
+			base.Exec = nil
+			base.Graphql = &executable.GraphQLExecutableType{Endpoint: "https://dev.example.com/graphql"}
+			e := &executable.Executable{
+				Verb: "deploy", Name: "prod", Extends: "deploy base",
+				Exec: &executable.ExecExecutableType{Cmd: "echo"},
+			}
+			e.SetContext(testWsName, testWorkspacePath, "", "prod.flow")
+			_, err := e.Extend(base, nil)
+			Expect(err).To(MatchError(ContainSubstring("cannot extend a Graphql executable with Exec configuration")))
+		})
+
+		It("should fail when the base has no type", func() {
+			base.Exec = nil
+			e := &executable.Executable{
+				Verb: "deploy", Name: "prod", Extends: "deploy base",
+				Exec: &executable.ExecExecutableType{Cmd: "echo"},
+			}
+			e.SetContext(testWsName, testWorkspacePath, "", "prod.flow")
+			_, err := e.Extend(base, nil)
+			Expect(err).To(MatchError(ContainSubstring("without a type")))
+		})
+
+		It("should expand the workspace of the extends reference", func() {
+			e := &executable.Executable{Verb: "deploy", Name: "prod", Extends: "deploy ns:base"}
+			e.SetContext(testWsName, testWorkspacePath, "", "prod.flow")
+			Expect(e.ExtendsRef()).To(Equal(executable.Ref("deploy workspace/ns:base")))
+		})
+	})
+
 	DescribeTable("IsVisibleFromWorkspace", func(visibility *common.Visibility, wsMatch, expected bool) {
 		v := executable.ExecutableVisibility(*visibility)
 		exec.Visibility = &v
@@ -373,6 +585,28 @@ var _ = DescribeTable("HasFlowFileTemplateExt", func(file string, expected bool)
 	Entry("ends with something else", "development.flow.txt", false),
 )
 
+var _ = DescribeTable("MustParseExecutableID with remote workspaces", func(id, ws, ns, name string) {
+	parsedWs, parsedNs, parsedName := executable.MustParseExecutableID(id)
+	Expect(parsedWs).To(Equal(ws))
+	Expect(parsedNs).To(Equal(ns))
+	Expect(parsedName).To(Equal(name))
+	Expect(executable.IsRemoteWorkspace(parsedWs)).To(BeTrue())
+},
+	Entry("with namespace", "github.com/org/tools@v1.2.0/k8s:deploy",
+		"github.com/org/tools@v1.2.0", "k8s", "deploy"),
+	Entry("without namespace", "github.com/org/tools@v1.2.0/deploy",
+		"github.com/org/tools@v1.2.0", executable.WildcardNamespace, "deploy"),
+	Entry("with URL scheme", "file:///tmp/tools@main/k8s:deploy", "file:///tmp/tools@main", "k8s", "deploy"),
+)
+
+var _ = DescribeTable("RemoteGitURL", func(repo, expected string) {
+	Expect(executable.RemoteGitURL(repo)).To(Equal(expected))
+},
+	Entry("module-style path", "github.com/org/tools", "https://github.com/org/tools.git"),
+	Entry("path with .git suffix", "github.com/org/tools.git", "https://github.com/org/tools.git"),
+	Entry("URL", "https://git.example.com/org/tools.git", "https://git.example.com/org/tools.git"),
+)
+
 var _ = Describe("Executable Visibility", func() {
 	It("should show public executables with any filter", func() {
 		ws := "ws1"
@@ -0,0 +1,162 @@
+package executable
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ExtendsRef returns the fully qualified reference of the executable that e extends. The workspace defaults to
+// the executable's own when it is omitted from the `extends` reference.
+func (e *Executable) ExtendsRef() Ref {
+	if e.Extends == "" {
+		return ""
+	}
+	ws, ns, name := MustParseExecutableID(e.Extends.ID())
+	if ws == "" || ws == WildcardWorkspace {
+		ws = e.Workspace()
+	}
+	if ns == "" {
+		ns = e.Namespace()
+	}
+	return NewRef(NewExecutableID(ws, ns, name), e.Extends.Verb())
+}
+
+// Extend returns a new executable built from base with the fields set on e merged over it. The result takes e's
+// identity (verb, name, aliases, and context) and is completed with the flow file's inherited fields and defaults,
+// so e should not have had SetInheritedFields or SetDefaults applied yet.
+//
+// Scalars set on e replace the base values, maps are merged, params and args are merged by env key, tags are
+// combined, and any other lists replace the base list. The name, aliases, verb aliases, and visibility are never
+// taken from base. Only fields that are set on e are merged, so a zero value (e.g. `false` or `0`) can't override a
+// value set on base.
+func (e *Executable) Extend(base *Executable, flowFile *FlowFile) (*Executable, error) {
+	merged, err := copyExecutable(base)
+	if err != nil {
+		return nil, fmt.Errorf("unable to copy %s - %w", base.Ref(), err)
+	}
+
+	merged.Verb = e.Verb
+	merged.VerbAliases = e.VerbAliases
+	merged.Name = e.Name
+	merged.Aliases = e.Aliases
+	merged.Visibility = e.Visibility
+	merged.Extends = e.Extends
+	if e.Description != "" {
+		merged.Description = e.Description
+	}
+	if e.Timeout != nil {
+		t := *e.Timeout
+		merged.Timeout = &t
+	}
+	for _, tag := range e.Tags {
+		if !slices.Contains(merged.Tags, tag) {
+			merged.Tags = append(merged.Tags, tag)
+		}
+	}
+	if len(e.Annotations) > 0 {
+		if merged.Annotations == nil {
+			merged.Annotations = make(ExecutableAnnotations)
+		}
+		maps.Copy(merged.Annotations, e.Annotations)
+	}
+
+	if err := mergeExecutableType(merged, e); err != nil {
+		return nil, err
+	}
+
+	merged.SetContext(e.Workspace(), e.WorkspacePath(), e.Namespace(), e.FlowFilePath())
+	if flowFile != nil {
+		merged.SetInheritedFields(flowFile)
+	}
+	merged.SetDefaults()
+	return merged, nil
+}
+
+func copyExecutable(e *Executable) (*Executable, error) {
+	data, err := yaml.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	c := &Executable{}
+	if err := yaml.Unmarshal(data, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// mergeExecutableType deep-merges the type configuration set on src (if any) into dst. The two executables must
+// be of the same type.
+func mergeExecutableType(dst, src *Executable) error {
+	dstType, dstField := typeField(dst)
+	srcType, srcField := typeField(src)
+	switch {
+	case !srcType.IsValid():
+		return nil
+	case !dstType.IsValid():
+		return fmt.Errorf("cannot extend an executable without a type with %s configuration", srcField)
+	case dstField != srcField:
+		return fmt.Errorf("cannot extend a %s executable with %s configuration", dstField, srcField)
+	}
+	mergeValue(dstType.Elem(), srcType.Elem())
+	return nil
+}
+
+// typeField returns the settable pointer value and field name of the executable's type configuration.
+func typeField(e *Executable) (reflect.Value, string) {
+	v := reflect.ValueOf(e).Elem()
+	for _, name := range []string{"Exec", "Launch", "Request", "Graphql", "Render", "Serial", "Parallel"} {
+		f := v.FieldByName(name)
+		if !f.IsNil() {
+			return f, name
+		}
+	}
+	return reflect.Value{}, ""
+}
+
+// mergeValue merges src into dst. Zero-valued struct fields of src are skipped since they can't be told apart from
+// fields that aren't set.
+func mergeValue(dst, src reflect.Value) {
+	switch src.Interface().(type) {
+	case ParameterList:
+		merged, _ := mergeByEnvKey(dst.Interface().(ParameterList), src.Interface().(ParameterList), paramKey)
+		dst.Set(reflect.ValueOf(ParameterList(merged)))
+		return
+	case ArgumentList:
+		merged, _ := mergeByEnvKey(dst.Interface().(ArgumentList), src.Interface().(ArgumentList), argKey)
+		dst.Set(reflect.ValueOf(ArgumentList(merged)))
+		return
+	}
+
+	switch src.Kind() {
+	case reflect.Struct:
+		for i := 0; i < src.NumField(); i++ {
+			if !dst.Field(i).CanSet() {
+				continue
+			}
+			if src.Field(i).IsZero() {
+				continue
+			}
+			mergeValue(dst.Field(i), src.Field(i))
+		}
+	case reflect.Ptr:
+		if dst.IsNil() || src.Elem().Kind() != reflect.Struct {
+			dst.Set(src)
+			return
+		}
+		mergeValue(dst.Elem(), src.Elem())
+	case reflect.Map:
+		if dst.IsNil() {
+			dst.Set(reflect.MakeMap(src.Type()))
+		}
+		iter := src.MapRange()
+		for iter.Next() {
+			dst.SetMapIndex(iter.Key(), iter.Value())
+		}
+	default:
+		dst.Set(src)
+	}
+}
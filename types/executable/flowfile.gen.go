@@ -3,6 +3,8 @@
 package executable
 
 import "github.com/flowexec/flow/v2/types/common"
+import "github.com/flowexec/tuikit/io"
+import "time"
 
 // Configuration for a group of Flow CLI executables. The file must have the
 // extension `.flow`, `.flow.yaml`, or `.flow.yml`
@@ -14,9 +16,20 @@ type FlowFile struct {
 	// Annotations corresponds to the JSON schema field "annotations".
 	Annotations FlowFileAnnotations `json:"annotations,omitempty" yaml:"annotations,omitempty" mapstructure:"annotations,omitempty"`
 
+	// Arguments to be applied to all executables defined within the flow file.
+	// An executable's own arguments take precedence over flow file arguments with the
+	// same `envKey`.
+	//
+	Args ArgumentList `json:"args,omitempty" yaml:"args,omitempty" mapstructure:"args,omitempty"`
+
 	// configPath corresponds to the JSON schema field "configPath".
 	configPath string `json:"configPath,omitempty" yaml:"configPath,omitempty" mapstructure:"configPath,omitempty"`
 
+	// The default container for all `exec` executables defined within the flow file.
+	// Only used when the executable does not set its own `container`.
+	//
+	Container *FlowFileContainer `json:"container,omitempty" yaml:"container,omitempty" mapstructure:"container,omitempty"`
+
 	// A description of the executables defined within the flow file. This description
 	// will used as a shared description
 	// for all executables in the flow file.
@@ -27,12 +40,23 @@ type FlowFile struct {
 	// defined within the flow file.
 	DescriptionFile string `json:"descriptionFile,omitempty" yaml:"descriptionFile,omitempty" mapstructure:"descriptionFile,omitempty"`
 
+	// The default directory for all `exec`, `render`, `serial`, and `parallel`
+	// executables defined within the flow file.
+	// Only used when the executable does not set its own `dir`.
+	//
+	Dir Directory `json:"dir,omitempty" yaml:"dir,omitempty" mapstructure:"dir,omitempty"`
+
 	// Executables corresponds to the JSON schema field "executables".
 	Executables ExecutableList `json:"executables,omitempty" yaml:"executables,omitempty" mapstructure:"executables,omitempty"`
 
 	// Imports corresponds to the JSON schema field "imports".
 	Imports Imports `json:"imports,omitempty" yaml:"imports,omitempty" mapstructure:"imports,omitempty"`
 
+	// The default log mode for all `exec` executables defined within the flow file.
+	// Only used when the executable does not set its own `logMode`.
+	//
+	LogMode io.LogMode `json:"logMode,omitempty" yaml:"logMode,omitempty" mapstructure:"logMode,omitempty"`
+
 	// The namespace to be given to all executables in the flow file.
 	// If not set, the executables in the file will be grouped into the root (*)
 	// namespace.
@@ -43,9 +67,21 @@ type FlowFile struct {
 	//
 	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty" mapstructure:"namespace,omitempty"`
 
+	// Parameters to be applied to all executables defined within the flow file.
+	// An executable's own parameters take precedence over flow file parameters with
+	// the same `envKey`.
+	//
+	Params ParameterList `json:"params,omitempty" yaml:"params,omitempty" mapstructure:"params,omitempty"`
+
 	// Tags to be applied to all executables defined within the flow file.
 	Tags []string `json:"tags,omitempty" yaml:"tags,omitempty" mapstructure:"tags,omitempty"`
 
+	// The default timeout for all executables defined within the flow file.
+	// Only used when the executable does not set its own `timeout`. Takes precedence
+	// over the user config `defaultTimeout`.
+	//
+	Timeout *time.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty" mapstructure:"timeout,omitempty"`
+
 	// Visibility corresponds to the JSON schema field "visibility".
 	Visibility *FlowFileVisibility `json:"visibility,omitempty" yaml:"visibility,omitempty" mapstructure:"visibility,omitempty"`
 
@@ -58,8 +94,19 @@ type FlowFile struct {
 
 type FlowFileAnnotations common.Annotations
 
+// The default container for all `exec` executables defined within the flow file.
+// Only used when the executable does not set its own `container`.
+type FlowFileContainer ExecContainer
+
 type FlowFileVisibility common.Visibility
 
-// A list of script files (`.sh`, `.bat`, `.cmd`, `.ps1`) to convert into generated
-// executables in the file's executable group.
+// A list of files to import executables from into the file's executable group.
+// Script files (`.sh`, `.bat`, `.cmd`, `.ps1`),
+// Makefiles, justfiles, Taskfiles, package.json, pyproject.toml and VS Code
+// tasks.json files, compose files, GitHub Actions workflows, `.http` files, and
+// OpenAPI specs (`openapi.yaml`, `openapi.json`) are converted into generated
+// executables. Flow files, and
+// other YAML files that declare the flow file schema in a `# yaml-language-server:
+// $schema=...` comment, are included. Entries can be glob patterns or flow files
+// pinned in a git repository (`<repo-url>.git//<path>?ref=<ref>`).
 type Imports []string
@@ -27,8 +27,14 @@ func (f *FlowFile) SetContext(workspaceName, workspacePath, configPath string) {
 			v := ExecutableVisibility(*f.Visibility)
 			exec.Visibility = &v
 		}
-		exec.SetDefaults()
+		if exec.Extends != "" {
+			// Inherited fields and defaults are applied once the extended executable is resolved (see Extend).
+			continue
+		}
+		// Inherited fields are applied first so that flow file defaults (e.g. timeout) take precedence
+		// over the global defaults.
 		exec.SetInheritedFields(f)
+		exec.SetDefaults()
 	}
 }
 
@@ -0,0 +1,48 @@
+package executable
+
+import (
+	"strings"
+)
+
+// A remote workspace references a git repository at a version instead of a registered workspace, in the form
+// <repo>@<version> (e.g. github.com/org/tools@v1.2.0). Its executables are referenced like any other, e.g.
+// `exec github.com/org/tools@v1.2.0/k8s:deploy`.
+
+// ParseRemoteWorkspace splits a remote workspace into its repository and version. ok is false when ws isn't a
+// remote workspace.
+func ParseRemoteWorkspace(ws string) (repo, version string, ok bool) {
+	at := strings.LastIndex(ws, "@")
+	if at <= 0 || at == len(ws)-1 || strings.Contains(ws[at:], "/") {
+		return "", "", false
+	}
+	return ws[:at], ws[at+1:], true
+}
+
+// IsRemoteWorkspace returns true if ws references a git repository at a version.
+func IsRemoteWorkspace(ws string) bool {
+	_, _, ok := ParseRemoteWorkspace(ws)
+	return ok
+}
+
+// RemoteGitURL returns the URL used to clone a remote workspace's repository. Repositories are referenced like Go
+// modules (host/path) and cloned over HTTPS, unless they already include a URL scheme.
+func RemoteGitURL(repo string) string {
+	if strings.Contains(repo, "://") {
+		return repo
+	}
+	return "https://" + strings.TrimSuffix(repo, ".git") + ".git"
+}
+
+// splitRemoteID splits an executable ID that starts with a remote workspace into the workspace and the
+// rest of the ID (the namespace and name).
+func splitRemoteID(id string) (ws, rest string, ok bool) {
+	at := strings.Index(id, "@")
+	if at <= 0 {
+		return "", "", false
+	}
+	slash := strings.Index(id[at:], "/")
+	if slash < 0 {
+		return id, "", true
+	}
+	return id[:at+slash], id[at+slash+1:], true
+}
@@ -0,0 +1,131 @@
+package executable
+
+import (
+	"fmt"
+
+	"github.com/flowexec/flow/v2/internal/utils"
+)
+
+// Validate performs semantic validation that the JSON schema cannot express.
+func (r *RequestExecutableType) Validate() error {
+	if r == nil {
+		return nil
+	}
+	// body is the fallback for a bodyFile that's set from the environment, so they can be set together.
+	var bodies int
+	for _, set := range []bool{
+		r.Body != "" || r.BodyFile != "", r.RawBody != "", len(r.Form) > 0, r.Multipart != nil,
+	} {
+		if set {
+			bodies++
+		}
+	}
+	if bodies > 1 {
+		return fmt.Errorf("must define only one of body, rawBody, bodyFile, form or multipart")
+	}
+	if r.Tls != nil {
+		if err := optionalOneOf("tls client certificate", r.Tls.CertFile, r.Tls.CertSecretRef); err != nil {
+			return err
+		}
+		if err := optionalOneOf("tls client key", r.Tls.KeyFile, r.Tls.KeySecretRef); err != nil {
+			return err
+		}
+	}
+	if r.MaxRedirects != nil && *r.MaxRedirects < 0 {
+		return fmt.Errorf("maxRedirects must not be negative")
+	}
+	if r.Poll != nil && r.Poll.Until == "" {
+		return fmt.Errorf("poll until expression cannot be empty")
+	}
+	if r.Poll != nil && r.Paginate != nil {
+		return fmt.Errorf("must define only one of poll or paginate")
+	}
+	if r.Paginate != nil && r.Paginate.Strategy == RequestPaginateStrategyCursor && r.Paginate.Cursor == "" {
+		return fmt.Errorf("paginate cursor expression is required for the cursor strategy")
+	}
+	if err := r.Auth.Validate(); err != nil {
+		return fmt.Errorf("auth validation failed - %w", err)
+	}
+	return nil
+}
+
+// Validate performs semantic validation that the JSON schema cannot express.
+func (g *GraphQLExecutableType) Validate() error {
+	if g == nil {
+		return nil
+	}
+	if err := utils.ValidateOneOf("graphql query", g.Query, g.QueryFile); err != nil {
+		return err
+	}
+	if g.Tls != nil {
+		if err := optionalOneOf("tls client certificate", g.Tls.CertFile, g.Tls.CertSecretRef); err != nil {
+			return err
+		}
+		if err := optionalOneOf("tls client key", g.Tls.KeyFile, g.Tls.KeySecretRef); err != nil {
+			return err
+		}
+	}
+	if err := g.Auth.Validate(); err != nil {
+		return fmt.Errorf("auth validation failed - %w", err)
+	}
+	return nil
+}
+
+// Validate performs semantic validation that the JSON schema cannot express.
+// It is only invoked when an auth block is present.
+func (a *RequestAuth) Validate() error {
+	if a == nil {
+		return nil
+	}
+	if err := utils.ValidateOneOf("auth method", a.Basic, a.Bearer, a.ApiKey, a.Oauth2); err != nil {
+		return err
+	}
+	switch {
+	case a.Basic != nil:
+		if err := optionalOneOf("basic auth username", a.Basic.Username, a.Basic.UsernameSecretRef); err != nil {
+			return err
+		}
+		return optionalOneOf("basic auth password", a.Basic.Password, a.Basic.PasswordSecretRef)
+	case a.Bearer != nil:
+		return utils.ValidateOneOf("bearer auth token", a.Bearer.Token, a.Bearer.TokenSecretRef)
+	case a.ApiKey != nil:
+		if a.ApiKey.Name == "" {
+			return fmt.Errorf("api key auth name cannot be empty")
+		}
+		return utils.ValidateOneOf("api key auth value", a.ApiKey.Value, a.ApiKey.SecretRef)
+	case a.Oauth2 != nil:
+		if a.Oauth2.TokenURL == "" {
+			return fmt.Errorf("oauth2 auth tokenURL cannot be empty")
+		}
+		if err := utils.ValidateOneOf("oauth2 auth client ID", a.Oauth2.ClientId, a.Oauth2.ClientIdSecretRef); err != nil {
+			return err
+		}
+		return utils.ValidateOneOf("oauth2 auth client secret", a.Oauth2.ClientSecret, a.Oauth2.ClientSecretRef)
+	}
+	return nil
+}
+
+// optionalOneOf returns an error when both a value and its secret reference are set.
+func optionalOneOf(fieldName, value, secretRef string) error {
+	if value != "" && secretRef != "" {
+		return fmt.Errorf("must define only one %s", fieldName)
+	}
+	return nil
+}
+
+// method returns the name of the auth method that's set, or "" when there's none.
+func (a *RequestAuth) method() string {
+	switch {
+	case a == nil:
+		return ""
+	case a.Basic != nil:
+		return "basic"
+	case a.Bearer != nil:
+		return "bearer"
+	case a.ApiKey != nil:
+		return "api key"
+	case a.Oauth2 != nil:
+		return "oauth2"
+	}
+	return ""
+}
@@ -20,6 +20,50 @@ type ExecutableFilter struct {
 	Included []string `json:"included,omitempty" yaml:"included,omitempty" mapstructure:"included,omitempty"`
 }
 
+// A named environment profile for the workspace (e.g. `dev`, `staging`, `prod`).
+// When a profile is active, its env files and params are layered on top of the
+// workspace env files,
+// its vault is used to resolve secret references, and its args are passed to the
+// executable before any
+// arguments provided on the command line.
+type Profile struct {
+	// Default arguments passed to the executable when the profile is active (e.g.
+	// `--region=us-east-1`).
+	// Arguments provided on the command line are applied after these, so flag values
+	// provided there take precedence.
+	//
+	Args []string `json:"args,omitempty" yaml:"args,omitempty" mapstructure:"args,omitempty"`
+
+	// A description of the profile.
+	Description string `json:"description,omitempty" yaml:"description,omitempty" mapstructure:"description,omitempty"`
+
+	// A list of environment variable files to load when the profile is active.
+	// Relative paths are resolved
+	// from the workspace root. These are loaded after the workspace `envFiles` so
+	// their values take precedence.
+	//
+	EnvFiles []string `json:"envFiles,omitempty" yaml:"envFiles,omitempty" mapstructure:"envFiles,omitempty"`
+
+	// A map of environment variable names to static values that are set when the
+	// profile is active.
+	// These take precedence over values loaded from env files but can still be
+	// overridden with `--param`.
+	//
+	Params map[string]string `json:"params,omitempty" yaml:"params,omitempty" mapstructure:"params,omitempty"`
+
+	// When true, flow asks for confirmation before running any executable with this
+	// profile active.
+	// Use `--yes` to skip the prompt in non-interactive environments.
+	//
+	RequireConfirmation bool `json:"requireConfirmation,omitempty" yaml:"requireConfirmation,omitempty" mapstructure:"requireConfirmation,omitempty"`
+
+	// The name of the vault used to resolve secret references when the profile is
+	// active.
+	// If not set, the current vault is used.
+	//
+	Vault string `json:"vault,omitempty" yaml:"vault,omitempty" mapstructure:"vault,omitempty"`
+}
+
 // A map of executable verbs to valid aliases. This allows you to use custom
 // aliases for exec commands in the workspace.
 // Setting this will override all of the default flow command aliases. The verbs
@@ -78,6 +122,19 @@ type Workspace struct {
 	// location corresponds to the JSON schema field "location".
 	location string `json:"location,omitempty" yaml:"location,omitempty" mapstructure:"location,omitempty"`
 
+	// Named environment profiles for the workspace. The active profile is selected
+	// with the `--profile` flag
+	// on `flow exec`, the `FLOW_PROFILE` environment variable, or the
+	// `currentProfile` user config setting.
+	//
+	Profiles WorkspaceProfiles `json:"profiles,omitempty" yaml:"profiles,omitempty" mapstructure:"profiles,omitempty"`
+
+	// Default HTTP client settings (TLS, proxy and redirects) for the request
+	// executables of the workspace.
+	// Executables can override each setting.
+	//
+	RequestDefaults *WorkspaceRequestDefaults `json:"requestDefaults,omitempty" yaml:"requestDefaults,omitempty" mapstructure:"requestDefaults,omitempty"`
+
 	// Tags corresponds to the JSON schema field "tags".
 	Tags WorkspaceTags `json:"tags,omitempty" yaml:"tags,omitempty" mapstructure:"tags,omitempty"`
 
@@ -103,6 +160,17 @@ const WorkspaceGitRefTypeBlank WorkspaceGitRefType = ""
 const WorkspaceGitRefTypeBranch WorkspaceGitRefType = "branch"
 const WorkspaceGitRefTypeTag WorkspaceGitRefType = "tag"
 
+// Named environment profiles for the workspace. The active profile is selected
+// with the `--profile` flag
+// on `flow exec`, the `FLOW_PROFILE` environment variable, or the `currentProfile`
+// user config setting.
+type WorkspaceProfiles map[string]Profile
+
+// Default HTTP client settings (TLS, proxy and redirects) for the request
+// executables of the workspace.
+// Executables can override each setting.
+type WorkspaceRequestDefaults common.RequestClient
+
 type WorkspaceTags common.Tags
 
 type WorkspaceVerbAliases map[string][]string
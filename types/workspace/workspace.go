@@ -49,6 +49,18 @@ func (w *Workspace) SetContext(name, location string) {
 	w.location = location
 }
 
+// Profile returns the environment profile with the given name, if the workspace defines one.
+func (w *Workspace) Profile(name string) (*Profile, bool) {
+	if name == "" || w.Profiles == nil {
+		return nil, false
+	}
+	p, ok := w.Profiles[name]
+	if !ok {
+		return nil, false
+	}
+	return &p, true
+}
+
 func (w *Workspace) YAML() (string, error) {
 	yamlBytes, err := yaml.Marshal(w.enriched())
 	if err != nil {